@@ -0,0 +1,173 @@
+// Command comfyent-cli runs common operations on a comfyent database:
+//
+//	comfyent-cli migrate    --db ent.db
+//	comfyent-cli seed       --db ent.db [users.json]
+//	comfyent-cli backup     --db ent.db [--overwrite] backup.db
+//	comfyent-cli export     --db ent.db [--out users.json]
+//	comfyent-cli list-users --db ent.db
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/seed"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	_ "github.com/davidroman0O/comfylite3-ent/ent/runtime"
+)
+
+// command runs a subcommand on the opened database with the arguments left after its flags.
+type command struct {
+	usage string
+	run   func(ctx context.Context, db *database, args []string, stdout io.Writer) error
+	// flags registers the flags of the subcommand, besides --db.
+	flags func(fs *flag.FlagSet)
+}
+
+type database struct {
+	client *ent.Client
+	comfy  *comfylite3.ComfyDB
+}
+
+var (
+	overwrite bool
+	out       string
+)
+
+var commands = map[string]command{
+	"migrate": {
+		usage: "create or update the schema",
+		run: func(ctx context.Context, db *database, _ []string, stdout io.Writer) error {
+			if err := db.client.Schema.Create(ctx, comfyent.WithCaseInsensitiveEmail()); err != nil {
+				return err
+			}
+			fmt.Fprintln(stdout, "schema up to date")
+			return nil
+		},
+	},
+	"seed": {
+		usage: "seed the users of a JSON file, or the sample users",
+		run: func(ctx context.Context, db *database, args []string, _ io.Writer) error {
+			if len(args) == 0 {
+				return seed.SeedFixtures(ctx, db.client)
+			}
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			return seed.SeedFromJSON(ctx, db.client, f)
+		},
+	},
+	"backup": {
+		usage: "write a snapshot of the database to the given path",
+		flags: func(fs *flag.FlagSet) {
+			fs.BoolVar(&overwrite, "overwrite", false, "replace an existing backup")
+		},
+		run: func(ctx context.Context, db *database, args []string, _ io.Writer) error {
+			if len(args) != 1 {
+				return errors.New("backup takes the destination path")
+			}
+			var opts []comfyent.BackupOption
+			if overwrite {
+				opts = append(opts, comfyent.WithOverwrite())
+			}
+			return comfyent.Backup(ctx, db.comfy, args[0], opts...)
+		},
+	},
+	"export": {
+		usage: "export every user as JSON",
+		flags: func(fs *flag.FlagSet) {
+			fs.StringVar(&out, "out", "", "file to write to instead of stdout")
+		},
+		run: func(ctx context.Context, db *database, _ []string, stdout io.Writer) error {
+			if out == "" {
+				return comfyent.ExportUsersJSON(ctx, db.client, stdout)
+			}
+			f, err := os.Create(out)
+			if err != nil {
+				return err
+			}
+			if err := comfyent.ExportUsersJSON(ctx, db.client, f); err != nil {
+				f.Close()
+				return err
+			}
+			return f.Close()
+		},
+	},
+	"list-users": {
+		usage: "print the ID, name and email of every user",
+		run: func(ctx context.Context, db *database, _ []string, stdout io.Writer) error {
+			tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(tw, "ID\tNAME\tEMAIL")
+			err := comfyent.IterateUsers(ctx, db.client, func(u *ent.User) error {
+				_, err := fmt.Fprintf(tw, "%d\t%s\t%s\n", u.ID, u.Name, u.Email)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return tw.Flush()
+		},
+	},
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "comfyent-cli: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return errors.New("missing command")
+	}
+	name := args[0]
+	cmd, ok := commands[name]
+	if !ok {
+		usage(stderr)
+		return fmt.Errorf("unknown command %q", name)
+	}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("db", "ent.db", "path of the database file")
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	client, comfy, err := comfyent.Open(comfyent.Config{Path: *path, ForeignKeys: true})
+	if err != nil {
+		return err
+	}
+	defer comfy.Close()
+	defer client.Close()
+	if name != "migrate" {
+		if err := comfyent.EnsureSchema(ctx, client); errors.Is(err, comfyent.ErrSchemaMissing) {
+			return fmt.Errorf("%s has no schema, run comfyent-cli migrate --db %[1]s first", *path)
+		} else if err != nil {
+			return err
+		}
+	}
+	if err := cmd.run(ctx, &database{client: client, comfy: comfy}, fs.Args(), stdout); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: comfyent-cli <command> [--db path] [flags] [args]")
+	for _, name := range []string{"migrate", "seed", "backup", "export", "list-users"} {
+		fmt.Fprintf(w, "  %-10s  %s\n", name, commands[name].usage)
+	}
+}
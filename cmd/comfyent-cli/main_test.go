@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunListUsers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ent.db")
+	users := filepath.Join(dir, "users.json")
+	if err := os.WriteFile(users, []byte(`[
+		{"name": "ada", "age": 36, "email": "ada@example.com"},
+		{"name": "grace", "age": 45, "email": "grace@example.com"}
+	]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	var stdout, stderr bytes.Buffer
+
+	if err := run(ctx, []string{"list-users", "--db", path}, &stdout, &stderr); err == nil || !strings.Contains(err.Error(), "migrate") {
+		t.Fatalf("got %v listing the users of a database without schema, want a hint to migrate", err)
+	}
+	for _, args := range [][]string{
+		{"migrate", "--db", path},
+		{"seed", "--db", path, users},
+	} {
+		if err := run(ctx, args, &stdout, &stderr); err != nil {
+			t.Fatalf("%s: %v", args[0], err)
+		}
+	}
+
+	stdout.Reset()
+	if err := run(ctx, []string{"list-users", "--db", path}, &stdout, &stderr); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got output %q, want a header and 2 users", stdout.String())
+	}
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "ID NAME EMAIL" {
+		t.Fatalf("got header %q, want ID NAME EMAIL", lines[0])
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "1 ada ada@example.com" {
+		t.Fatalf("got line %q, want ada", lines[1])
+	}
+	if fields := strings.Fields(lines[2]); strings.Join(fields, " ") != "2 grace grace@example.com" {
+		t.Fatalf("got line %q, want grace", lines[2])
+	}
+
+	if err := run(ctx, []string{"unknown"}, &stdout, &stderr); err == nil {
+		t.Fatal("ran an unknown command")
+	}
+}
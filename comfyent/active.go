@@ -0,0 +1,27 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// DeactivateUser disables the account of the user with the given ID. The user is kept and still
+// returned by queries, filter with user.Active(true) or use ActiveUsers to leave it out.
+func DeactivateUser(ctx context.Context, client *ent.Client, id int) error {
+	if err := client.User.UpdateOneID(id).SetActive(false).Exec(ctx); err != nil {
+		return fmt.Errorf("deactivating user %d: %w", id, err)
+	}
+	return nil
+}
+
+// ActiveUsers returns the users whose account is active.
+func ActiveUsers(ctx context.Context, client *ent.Client) ([]*ent.User, error) {
+	users, err := client.User.Query().Where(user.Active(true)).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying active users: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,31 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestDeactivateUser(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	users := createManyUsers(t, client, 3)
+
+	if err := comfyent.DeactivateUser(ctx, client, users[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	active, err := comfyent.ActiveUsers(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 2 || active[0].ID == users[1].ID || active[1].ID == users[1].ID {
+		t.Fatalf("got %d active users, want the 2 others", len(active))
+	}
+	// The deactivated user is still there.
+	if got := client.User.GetX(ctx, users[1].ID); got.Active {
+		t.Fatal("the deactivated user is still active")
+	}
+}
@@ -0,0 +1,59 @@
+package comfyent_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestExportUsersArrowIPC(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	// Over two batches, so the stream holds several records.
+	want := createManyUsers(t, client, 1200)
+
+	var buf bytes.Buffer
+	if err := comfyent.ExportUsersArrowIPC(ctx, client, &buf); err != nil {
+		t.Fatalf("ExportUsersArrowIPC: %v", err)
+	}
+	reader, err := ipc.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Release()
+
+	i, records := 0, 0
+	for reader.Next() {
+		record := reader.Record()
+		records++
+		ids := record.Column(0).(*array.Int64)
+		names := record.Column(1).(*array.String)
+		ages := record.Column(2).(*array.Int32)
+		emails := record.Column(3).(*array.String)
+		for row := 0; row < int(record.NumRows()); row, i = row+1, i+1 {
+			if i >= len(want) {
+				t.Fatalf("got more than the %d users exported", len(want))
+			}
+			u := want[i]
+			if int(ids.Value(row)) != u.ID || names.Value(row) != u.Name || int(ages.Value(row)) != u.Age || emails.Value(row) != u.Email {
+				t.Fatalf("got user %d %s %d %s read back, want %d %s %d %s",
+					ids.Value(row), names.Value(row), ages.Value(row), emails.Value(row), u.ID, u.Name, u.Age, u.Email)
+			}
+		}
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != len(want) {
+		t.Fatalf("read %d users back, want %d", i, len(want))
+	}
+	if records != 3 {
+		t.Fatalf("got %d record batches, want 3", records)
+	}
+}
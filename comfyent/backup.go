@@ -0,0 +1,108 @@
+package comfyent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/davidroman0O/comfylite3"
+)
+
+type backupOptions struct {
+	overwrite bool
+}
+
+// BackupOption configures Backup.
+type BackupOption func(*backupOptions)
+
+// WithOverwrite lets Backup replace an existing file at the destination.
+func WithOverwrite() BackupOption {
+	return func(o *backupOptions) {
+		o.overwrite = true
+	}
+}
+
+// Backup writes a consistent snapshot of the database to destPath using VACUUM INTO.
+// The snapshot is taken within a single read transaction, so writers are not blocked
+// for the whole duration when WAL journaling is enabled.
+// It fails if destPath already exists, unless WithOverwrite is passed.
+func Backup(ctx context.Context, comfy *comfylite3.ComfyDB, destPath string, opts ...BackupOption) error {
+	cfg := backupOptions{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if _, err := os.Stat(destPath); err == nil {
+		if !cfg.overwrite {
+			return fmt.Errorf("backup destination %q already exists", destPath)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("checking backup destination: %w", err)
+	}
+
+	// Vacuum into a sibling file first so an existing backup is only replaced once the new one is complete.
+	tmp, err := os.CreateTemp(filepath.Dir(destPath), filepath.Base(destPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary backup file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	// VACUUM INTO refuses to write into an existing file.
+	if err := os.Remove(tmpPath); err != nil {
+		return fmt.Errorf("preparing temporary backup file: %w", err)
+	}
+	defer os.Remove(tmpPath)
+
+	if _, err := comfy.ExecContext(ctx, "VACUUM INTO ?;", tmpPath); err != nil {
+		return fmt.Errorf("backing up database: %w", err)
+	}
+	if !cfg.overwrite {
+		// Link fails if destPath appeared in the meantime, where Rename would silently replace it.
+		if err := os.Link(tmpPath, destPath); err != nil {
+			return fmt.Errorf("moving backup into place: %w", err)
+		}
+		return nil
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		return fmt.Errorf("moving backup into place: %w", err)
+	}
+	return nil
+}
+
+// SnapshotReader returns a consistent snapshot of the database as a stream, e.g. to upload it to
+// object storage without keeping a backup around. The snapshot is written by VACUUM INTO to a
+// temporary file, removed when the reader is closed.
+func SnapshotReader(ctx context.Context, comfy *comfylite3.ComfyDB) (io.ReadCloser, error) {
+	dir, err := os.MkdirTemp("", "comfyent-snapshot-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating snapshot directory: %w", err)
+	}
+	path := filepath.Join(dir, "snapshot.db")
+	if _, err := comfy.ExecContext(ctx, "VACUUM INTO ?;", path); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("taking snapshot: %w", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("opening snapshot: %w", err)
+	}
+	return &snapshot{File: f, dir: dir}, nil
+}
+
+// snapshot is a snapshot file deleted once closed.
+type snapshot struct {
+	*os.File
+	dir string
+}
+
+func (s *snapshot) Close() error {
+	err := s.File.Close()
+	if rerr := os.RemoveAll(s.dir); err == nil {
+		err = rerr
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package comfyent_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	_ "github.com/davidroman0O/comfylite3-ent/ent/runtime"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// openFile opens the database file at path with the schema created.
+func openFile(t *testing.T, path string) (*ent.Client, *comfylite3.ComfyDB) {
+	t.Helper()
+	client, comfy, err := comfyent.Open(comfyent.Config{Path: path, ForeignKeys: true})
+	if err != nil {
+		t.Fatalf("opening %s: %v", path, err)
+	}
+	t.Cleanup(func() {
+		client.Close()
+		comfy.Close()
+	})
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("creating schema: %v", err)
+	}
+	return client, comfy
+}
+
+// newFileClient opens a fresh database file of the test's temporary directory.
+func newFileClient(t *testing.T) (*ent.Client, *comfylite3.ComfyDB) {
+	t.Helper()
+	return openFile(t, filepath.Join(t.TempDir(), "test.db"))
+}
+
+func createUsers(t *testing.T, client *ent.Client, emails ...string) []*ent.User {
+	t.Helper()
+	users := make([]*ent.User, len(emails))
+	for i, email := range emails {
+		u, err := client.User.Create().SetName("user").SetAge(30).SetEmail(email).Save(context.Background())
+		if err != nil {
+			t.Fatalf("creating user %s: %v", email, err)
+		}
+		users[i] = u
+	}
+	return users
+}
+
+func TestBackup(t *testing.T) {
+	client, comfy := newFileClient(t)
+	ctx := context.Background()
+	createUsers(t, client, "a@example.com", "b@example.com")
+
+	dest := filepath.Join(t.TempDir(), "backup.db")
+	if err := comfyent.Backup(ctx, comfy, dest); err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	backup, _ := openFile(t, dest)
+	if n := backup.User.Query().CountX(ctx); n != 2 {
+		t.Fatalf("got %d users in the backup, want 2", n)
+	}
+}
+
+func TestBackupOverwrite(t *testing.T) {
+	client, comfy := newFileClient(t)
+	ctx := context.Background()
+	createUsers(t, client, "a@example.com")
+
+	dest := filepath.Join(t.TempDir(), "backup.db")
+	if err := os.WriteFile(dest, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := comfyent.Backup(ctx, comfy, dest); err == nil {
+		t.Fatal("Backup replaced an existing file without WithOverwrite")
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "old" {
+		t.Fatal("a failed Backup changed the existing file")
+	}
+	if err := comfyent.Backup(ctx, comfy, dest, comfyent.WithOverwrite()); err != nil {
+		t.Fatalf("Backup with WithOverwrite: %v", err)
+	}
+	backup, _ := openFile(t, dest)
+	if n := backup.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users in the backup, want 1", n)
+	}
+	// No temporary file is left behind.
+	if entries, _ := os.ReadDir(filepath.Dir(dest)); len(entries) != 1 {
+		t.Fatalf("got %d files next to the backup, want it alone", len(entries))
+	}
+}
+
+func TestSnapshotReader(t *testing.T) {
+	client, comfy := newFileClient(t)
+	ctx := context.Background()
+	createUsers(t, client, "a@example.com", "b@example.com", "c@example.com")
+
+	r, err := comfyent.SnapshotReader(ctx, comfy)
+	if err != nil {
+		t.Fatalf("SnapshotReader: %v", err)
+	}
+	dest := filepath.Join(t.TempDir(), "snapshot.db")
+	f, err := os.Create(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	// The temporary file goes away with the reader.
+	tmp := r.(interface{ Name() string }).Name()
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(tmp); !os.IsNotExist(err) {
+		t.Fatalf("got %v for the snapshot file once closed, want it removed", err)
+	}
+
+	restored, _ := openFile(t, dest)
+	emails := restored.User.Query().Order(user.ByEmail()).Select(user.FieldEmail).StringsX(ctx)
+	if len(emails) != 3 || emails[0] != "a@example.com" || emails[2] != "c@example.com" {
+		t.Fatalf("got users %q in the snapshot, want the 3 created", emails)
+	}
+}
@@ -0,0 +1,29 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// idsChunkSize is the number of IDs per IN list, under the 999 bound variables older SQLite builds allow.
+const idsChunkSize = 900
+
+// UsersByIDs loads the users with the given IDs, keyed by ID. IDs matching no user are left out
+// of the map. Each chunk of idsChunkSize IDs is loaded with a single IN query.
+func UsersByIDs(ctx context.Context, client *ent.Client, ids []int) (map[int]*ent.User, error) {
+	users := make(map[int]*ent.User, len(ids))
+	for start := 0; start < len(ids); start += idsChunkSize {
+		chunk := ids[start:min(start+idsChunkSize, len(ids))]
+		batch, err := client.User.Query().Where(user.IDIn(chunk...)).All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("loading users by ID: %w", err)
+		}
+		for _, u := range batch {
+			users[u.ID] = u
+		}
+	}
+	return users, nil
+}
@@ -0,0 +1,97 @@
+package comfyent_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// statementLog records the statements a client runs.
+type statementLog struct {
+	mu    sync.Mutex
+	stmts []string
+}
+
+// Since returns the statements starting with keyword, e.g. "SELECT", recorded after the first n.
+func (l *statementLog) Since(n int, keyword string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var stmts []string
+	for _, s := range l.stmts[n:] {
+		if strings.HasPrefix(s, keyword) {
+			stmts = append(stmts, s)
+		}
+	}
+	return stmts
+}
+
+// Len returns the number of statements recorded.
+func (l *statementLog) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.stmts)
+}
+
+// newRecordingClient returns a client on a fresh in-memory database, and the log of the
+// statements it runs.
+func newRecordingClient(t *testing.T) (*ent.Client, *statementLog) {
+	t.Helper()
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { comfy.Close() })
+	log := &statementLog{}
+	drv := driver.Wrap(sql.OpenDB(dialect.SQLite, comfyent.OpenDB(comfy, comfylite3.WithForeignKeys())), func(next driver.Handler) driver.Handler {
+		return func(ctx context.Context, stmt driver.Statement, v any) error {
+			log.mu.Lock()
+			log.stmts = append(log.stmts, stmt.Query)
+			log.mu.Unlock()
+			return next(ctx, stmt, v)
+		}
+	})
+	client := ent.NewClient(ent.Driver(driver.NewImplicitTxDriver(drv)))
+	t.Cleanup(func() { client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return client, log
+}
+
+func TestUsersByIDs(t *testing.T) {
+	client, log := newRecordingClient(t)
+	ctx := context.Background()
+	users := createManyUsers(t, client, 1500)
+
+	ids := make([]int, 0, len(users)+1)
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	// An ID matching no user.
+	ids = append(ids, users[len(users)-1].ID+1)
+
+	before := log.Len()
+	got, err := comfyent.UsersByIDs(ctx, client, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if selects := log.Since(before, "SELECT"); len(selects) != 2 {
+		t.Fatalf("ran %d queries for %d IDs, want 2 chunks", len(selects), len(ids))
+	}
+	if len(got) != len(users) {
+		t.Fatalf("got %d users, want %d", len(got), len(users))
+	}
+	for _, u := range users {
+		if got[u.ID] == nil || got[u.ID].Email != u.Email {
+			t.Fatalf("got %v for user %d, want %s", got[u.ID], u.ID, u.Email)
+		}
+	}
+}
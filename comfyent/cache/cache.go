@@ -0,0 +1,73 @@
+// Package cache keeps hot ent lookups in memory, or in any store implementing Cache.
+package cache
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/schema"
+)
+
+// Cache stores values by key. Implementations decide how long entries live and must be safe
+// for concurrent use.
+type Cache interface {
+	Get(key string) (any, bool)
+	Set(key string, val any)
+	Del(key string)
+}
+
+// userKey is the key a user is cached under.
+func userKey(id int) string {
+	return "user:" + strconv.Itoa(id)
+}
+
+// CachedUserByID returns the user with the given ID from cache, loading and caching it on a miss.
+// The returned user is shared by every caller hitting the cache, and must not be modified.
+//
+// A cached user is only returned to the callers who could load it: those of its tenant, see
+// comfyent.WithTenant, and if it is soft-deleted, those skipping soft deletes. Others load it
+// from the database, which decides.
+//
+// Entries are only invalidated by UpdateUserByID and DeleteUserByID: users changed through
+// other paths stay stale until the cache expires them.
+func CachedUserByID(ctx context.Context, client *ent.Client, cache Cache, id int) (*ent.User, error) {
+	if v, ok := cache.Get(userKey(id)); ok {
+		if u, ok := v.(*ent.User); ok && visible(ctx, u) {
+			return u, nil
+		}
+	}
+	u, err := client.User.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cache.Set(userKey(id), u)
+	return u, nil
+}
+
+// visible reports whether the context of a caller lets it see u.
+func visible(ctx context.Context, u *ent.User) bool {
+	if tenant, _ := comfyent.TenantFromContext(ctx); tenant != u.TenantID {
+		return false
+	}
+	return u.DeletedAt == nil || schema.SoftDeleteSkipped(ctx)
+}
+
+// UpdateUserByID applies mut to the user with the given ID and evicts it from cache, before and
+// after the write: a CachedUserByID loading the user meanwhile may have cached it as it was.
+func UpdateUserByID(ctx context.Context, client *ent.Client, cache Cache, id int, mut func(*ent.UserUpdateOne)) (*ent.User, error) {
+	cache.Del(userKey(id))
+	defer cache.Del(userKey(id))
+	update := client.User.UpdateOneID(id)
+	mut(update)
+	return update.Save(ctx)
+}
+
+// DeleteUserByID deletes the user with the given ID and evicts it from cache, before and after
+// the write, like UpdateUserByID.
+func DeleteUserByID(ctx context.Context, client *ent.Client, cache Cache, id int) error {
+	cache.Del(userKey(id))
+	defer cache.Del(userKey(id))
+	return client.User.DeleteOneID(id).Exec(ctx)
+}
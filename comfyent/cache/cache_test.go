@@ -0,0 +1,102 @@
+package cache_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/cache"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	_ "github.com/davidroman0O/comfylite3-ent/ent/runtime"
+	"github.com/davidroman0O/comfylite3-ent/ent/schema"
+)
+
+// newCountingClient returns a client on a fresh in-memory database, and the number of queries
+// it ran.
+func newCountingClient(t *testing.T) (*ent.Client, *atomic.Int64) {
+	t.Helper()
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { comfy.Close() })
+	var queries atomic.Int64
+	drv := driver.Wrap(sql.OpenDB(dialect.SQLite, comfyent.OpenDB(comfy, comfylite3.WithForeignKeys())), func(next driver.Handler) driver.Handler {
+		return func(ctx context.Context, stmt driver.Statement, v any) error {
+			if stmt.Op == driver.OpQuery {
+				queries.Add(1)
+			}
+			return next(ctx, stmt, v)
+		}
+	})
+	client := ent.NewClient(ent.Driver(driver.NewImplicitTxDriver(drv)))
+	t.Cleanup(func() { client.Close() })
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return client, &queries
+}
+
+func TestCachedUserByID(t *testing.T) {
+	client, queries := newCountingClient(t)
+	ctx := context.Background()
+	c := cache.NewMemory(time.Minute)
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+
+	before := queries.Load()
+	if _, err := cache.CachedUserByID(ctx, client, c, u.ID); err != nil {
+		t.Fatalf("first read: %v", err)
+	}
+	if n := queries.Load() - before; n != 1 {
+		t.Fatalf("first read ran %d queries, want 1", n)
+	}
+	got, err := cache.CachedUserByID(ctx, client, c, u.ID)
+	if err != nil {
+		t.Fatalf("second read: %v", err)
+	}
+	if n := queries.Load() - before; n != 1 {
+		t.Fatalf("second read ran %d queries, want none", n-1)
+	}
+	if got.ID != u.ID {
+		t.Fatalf("got user %d, want %d", got.ID, u.ID)
+	}
+
+	if _, err := cache.UpdateUserByID(ctx, client, c, u.ID, func(uu *ent.UserUpdateOne) { uu.SetAge(31) }); err != nil {
+		t.Fatalf("UpdateUserByID: %v", err)
+	}
+	if got, err := cache.CachedUserByID(ctx, client, c, u.ID); err != nil || got.Age != 31 {
+		t.Fatalf("got user %v and error %v after the update, want age 31", got, err)
+	}
+}
+
+func TestCachedUserByIDScope(t *testing.T) {
+	client, _ := newCountingClient(t)
+	comfyent.EnableTenancy(client)
+	ctx := context.Background()
+	c := cache.NewMemory(time.Minute)
+	a := comfyent.WithTenant(ctx, "a")
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(a)
+
+	if _, err := cache.CachedUserByID(a, client, c, u.ID); err != nil {
+		t.Fatalf("reading as the user's tenant: %v", err)
+	}
+	if _, err := cache.CachedUserByID(comfyent.WithTenant(ctx, "b"), client, c, u.ID); !ent.IsNotFound(err) {
+		t.Fatalf("got error %v reading as another tenant, want not found", err)
+	}
+
+	if err := cache.DeleteUserByID(a, client, c, u.ID); err != nil {
+		t.Fatalf("DeleteUserByID: %v", err)
+	}
+	if _, err := cache.CachedUserByID(schema.SkipSoftDelete(a), client, c, u.ID); err != nil {
+		t.Fatalf("reading the soft-deleted user skipping soft deletes: %v", err)
+	}
+	if _, err := cache.CachedUserByID(a, client, c, u.ID); !ent.IsNotFound(err) {
+		t.Fatalf("got error %v reading the soft-deleted user, want not found", err)
+	}
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Memory is an in-process Cache whose entries expire ttl after they were set.
+// Expired entries are dropped when next read.
+type Memory struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+type entry struct {
+	val     any
+	expires time.Time
+}
+
+// NewMemory returns an empty in-memory cache keeping entries for ttl.
+func NewMemory(ttl time.Duration) *Memory {
+	return &Memory{ttl: ttl, entries: make(map[string]entry)}
+}
+
+func (m *Memory) Get(key string) (any, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expires) {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return e.val, true
+}
+
+func (m *Memory) Set(key string, val any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = entry{val: val, expires: time.Now().Add(m.ttl)}
+}
+
+func (m *Memory) Del(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
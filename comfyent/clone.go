@@ -0,0 +1,37 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// CloneUser creates a copy of the user with the given ID, e.g. to use it as a template.
+// The fields of the user are copied, then overrides is called to change them, typically to give
+// the clone an email of its own since emails are unique. Edges aren't copied, and the clone
+// gets its own ID, timestamps and version.
+func CloneUser(ctx context.Context, client *ent.Client, id int, overrides func(*ent.UserCreate)) (*ent.User, error) {
+	src, err := client.User.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading user %d to clone: %w", id, err)
+	}
+	create := client.User.Create().
+		SetName(src.Name).
+		SetAge(src.Age).
+		SetEmail(src.Email).
+		SetRole(src.Role).
+		SetActive(src.Active).
+		SetMetadata(src.Metadata)
+	if src.TenantID != "" {
+		create.SetTenantID(src.TenantID)
+	}
+	if overrides != nil {
+		overrides(create)
+	}
+	clone, err := create.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cloning user %d: %w", id, err)
+	}
+	return clone, nil
+}
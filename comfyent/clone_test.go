@@ -0,0 +1,46 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestCloneUser(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	src := client.User.Create().
+		SetName("template").
+		SetAge(30).
+		SetEmail("template@example.com").
+		SetRole(user.RoleAdmin).
+		SetMetadata(map[string]any{"plan": "pro"}).
+		SaveX(ctx)
+	client.User.UpdateOne(src).SetAge(31).ExecX(ctx)
+
+	clone, err := comfyent.CloneUser(ctx, client, src.ID, func(c *ent.UserCreate) {
+		c.SetEmail("clone@example.com")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.ID == src.ID || clone.Email != "clone@example.com" {
+		t.Fatalf("got clone %d with email %s, want a new user with the overridden email", clone.ID, clone.Email)
+	}
+	if clone.Name != "template" || clone.Age != 31 || clone.Role != user.RoleAdmin || clone.Metadata["plan"] != "pro" {
+		t.Fatalf("got clone %+v, want the fields of the source", clone)
+	}
+	if clone.Version != 1 {
+		t.Fatalf("got version %d, want the clone to start over", clone.Version)
+	}
+
+	// Without an override the email clashes with the source's.
+	if _, err := comfyent.CloneUser(ctx, client, src.ID, nil); err == nil {
+		t.Fatal("cloned a user keeping its email")
+	}
+}
@@ -0,0 +1,52 @@
+// Package comfyenttest provides helpers to get an isolated ent client in tests.
+package comfyenttest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	_ "github.com/davidroman0O/comfylite3-ent/ent/runtime"
+)
+
+// dbCount keeps in-memory database names unique within the process.
+var dbCount atomic.Uint64
+
+// NewTestClient returns a client on a fresh in-memory database with the schema created,
+// and a cleanup func closing the client and the comfylite3 handle.
+//
+// Each call gets its own named shared-cache database, so tests never see each other's rows.
+func NewTestClient(t testing.TB) (*ent.Client, func()) {
+	t.Helper()
+
+	// Subtest names may hold any character, ? and # included, which would end the name in the URI.
+	name := url.PathEscape(t.Name())
+	comfy, err := comfylite3.New(
+		comfylite3.WithMemory(),
+		comfylite3.WithConnection(fmt.Sprintf("file:%s_%d?mode=memory&cache=shared&_mutex=full&_timeout=5000", name, dbCount.Add(1))),
+	)
+	if err != nil {
+		t.Fatalf("failed creating ComfyDB: %v", err)
+	}
+
+	db := comfyent.OpenDB(comfy, comfylite3.WithForeignKeys())
+	client := ent.NewClient(ent.Driver(driver.NewImplicitTxDriver(sql.OpenDB(dialect.SQLite, db))))
+	cleanup := func() {
+		client.Close()
+		comfy.Close()
+	}
+
+	if err := client.Schema.Create(context.Background()); err != nil {
+		cleanup()
+		t.Fatalf("failed creating schema resources: %v", err)
+	}
+	return client, cleanup
+}
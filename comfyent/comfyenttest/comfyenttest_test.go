@@ -0,0 +1,45 @@
+package comfyenttest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestNewTestClientIsolated(t *testing.T) {
+	ctx := context.Background()
+	first, cleanupFirst := comfyenttest.NewTestClient(t)
+	defer cleanupFirst()
+	second, cleanupSecond := comfyenttest.NewTestClient(t)
+	defer cleanupSecond()
+
+	first.User.Create().SetName("Alice").SetAge(30).SetEmail("alice@example.com").SaveX(ctx)
+	if n := second.User.Query().CountX(ctx); n != 0 {
+		t.Fatalf("got %d users in the second client, want 0", n)
+	}
+}
+
+func TestNewTestClientCleanup(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	cleanup()
+
+	if _, err := client.User.Query().Count(context.Background()); err == nil {
+		t.Fatal("querying succeeded after cleanup")
+	}
+}
+
+func TestNewTestClientSubtestNames(t *testing.T) {
+	for _, name := range []string{"a?b", "a#b", "a&b=c", "100%", "a b/c"} {
+		t.Run(name, func(t *testing.T) {
+			client, cleanup := comfyenttest.NewTestClient(t)
+			defer cleanup()
+			ctx := context.Background()
+
+			client.User.Create().SetName("Alice").SetAge(30).SetEmail("alice@example.com").SaveX(ctx)
+			if n := client.User.Query().CountX(ctx); n != 1 {
+				t.Fatalf("got %d users, want 1", n)
+			}
+		})
+	}
+}
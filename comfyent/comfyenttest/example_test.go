@@ -0,0 +1,32 @@
+package comfyenttest_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+// exampleT stands in for the *testing.T a test would pass, which examples don't get.
+type exampleT struct {
+	testing.TB
+}
+
+func (exampleT) Helper()      {}
+func (exampleT) Name() string { return "Example" }
+func (exampleT) Fatalf(format string, args ...any) {
+	log.Fatalf(format, args...)
+}
+
+func ExampleNewTestClient() {
+	// In a test, pass its *testing.T.
+	client, cleanup := comfyenttest.NewTestClient(exampleT{})
+	defer cleanup()
+	ctx := context.Background()
+
+	client.User.Create().SetName("Alice").SetAge(30).SetEmail("alice@example.com").SaveX(ctx)
+	fmt.Println(client.User.Query().CountX(ctx))
+	// Output: 1
+}
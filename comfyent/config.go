@@ -0,0 +1,197 @@
+package comfyent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/schematype"
+)
+
+// Config describes a database to open with Open, instead of assembling DSN flags by hand.
+type Config struct {
+	// Path is the database file. With InMemory it names the database instead, and can be
+	// left empty to get a private one.
+	Path string
+	// InMemory keeps the database in memory, it is gone once closed.
+	InMemory bool
+	// SharedCache enables SQLite's shared cache (cache=shared).
+	SharedCache bool
+	// ForeignKeys enforces foreign key constraints; Open fails if they end up disabled.
+	ForeignKeys bool
+	// JournalMode is the journal mode, e.g. "WAL" or "DELETE". Empty keeps SQLite's default.
+	JournalMode string
+	// BusyTimeout is how long to wait for a lock before failing with "database is locked".
+	// Zero keeps comfylite3's 5s.
+	BusyTimeout time.Duration
+	// Mode is the file access mode: "ro", "rw" or "rwc". Empty means "rwc". It is ignored
+	// for in-memory databases.
+	Mode string
+	// EncryptionKey, when set, is the AES key the client encrypts the email column with, see
+	// schematype.EncryptedString. Other clients of the process are left alone.
+	EncryptionKey []byte
+	// SerializeWrites runs the writes of the client one at a time, see driver.NewSerializedWriter.
+	// Writes then wait BusyTimeout for the lock.
+	SerializeWrites bool
+}
+
+// memoryDBs numbers the unnamed in-memory databases, so every one of them is private.
+var memoryDBs atomic.Int64
+
+// dsn returns the connection string for cfg, in the form comfylite3.WithConnection expects:
+// file databases keep a %s standing for the path.
+func (cfg Config) dsn() (string, error) {
+	params := []string{}
+	name := "%s"
+	if cfg.InMemory {
+		name = cfg.Path
+		if name == "" {
+			name = fmt.Sprintf("comfyent-%d", memoryDBs.Add(1))
+		}
+		params = append(params, "mode=memory")
+	} else {
+		if cfg.Path == "" {
+			return "", errors.New("config: a path is required unless InMemory is set")
+		}
+		switch cfg.Mode {
+		case "":
+			params = append(params, "mode=rwc")
+		case "ro", "rw", "rwc":
+			params = append(params, "mode="+cfg.Mode)
+		default:
+			return "", fmt.Errorf("config: unknown mode %q", cfg.Mode)
+		}
+	}
+	if cfg.SharedCache {
+		params = append(params, "cache=shared")
+	}
+	if cfg.ForeignKeys {
+		params = append(params, "_fk=1")
+	}
+	if cfg.JournalMode != "" {
+		params = append(params, "_journal_mode="+strings.ToUpper(cfg.JournalMode))
+	}
+	timeout := 5 * time.Second
+	if cfg.BusyTimeout > 0 {
+		timeout = cfg.BusyTimeout
+	}
+	params = append(params, fmt.Sprintf("_timeout=%d", timeout.Milliseconds()))
+	return "file:" + name + "?" + strings.Join(params, "&"), nil
+}
+
+// Open opens the database described by cfg and returns a client on it, along with the
+// comfylite3 handle, which the caller closes after the client.
+func Open(cfg Config) (*ent.Client, *comfylite3.ComfyDB, error) {
+	drv, comfy, err := openDriver(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ent.NewClient(ent.Driver(driver.NewImplicitTxDriver(drv))), comfy, nil
+}
+
+type debugOptions struct {
+	args bool
+}
+
+// DebugOption configures NewDebugClient.
+type DebugOption func(*debugOptions)
+
+// WithSQLArgs makes NewDebugClient log the bind arguments of statements, which it leaves out
+// by default.
+func WithSQLArgs() DebugOption {
+	return func(o *debugOptions) {
+		o.args = true
+	}
+}
+
+// NewDebugClient is like Open, but the client logs every statement it runs and its duration to
+// logger at debug level, see driver.NewSlogDriver. It is meant for development: Open doesn't log.
+func NewDebugClient(cfg Config, logger *slog.Logger, opts ...DebugOption) (*ent.Client, *comfylite3.ComfyDB, error) {
+	var o debugOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	drv, comfy, err := openDriver(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	client := ent.NewClient(
+		ent.Driver(driver.NewImplicitTxDriver(driver.NewSlogDriver(drv, logger, o.args))),
+		// Also route the output of client.Debug() to logger.
+		ent.Log(func(a ...any) { logger.Debug(fmt.Sprint(a...)) }),
+	)
+	return client, comfy, nil
+}
+
+// NewClientWithRetry is like Open, but the client retries the statements failing with a transient
+// error according to policy, see driver.NewRetryDriver.
+func NewClientWithRetry(cfg Config, policy driver.RetryPolicy) (*ent.Client, *comfylite3.ComfyDB, error) {
+	drv, comfy, err := openDriver(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ent.NewClient(ent.Driver(driver.NewImplicitTxDriver(driver.NewRetryDriver(drv, policy)))), comfy, nil
+}
+
+func openDriver(cfg Config) (dialect.Driver, *comfylite3.ComfyDB, error) {
+	dsn, err := cfg.dsn()
+	if err != nil {
+		return nil, nil, err
+	}
+	var cipher *schematype.Cipher
+	if cfg.EncryptionKey != nil {
+		if cipher, err = schematype.NewCipher(cfg.EncryptionKey); err != nil {
+			return nil, nil, err
+		}
+	}
+	opts := []comfylite3.ComfyOption{comfylite3.WithConnection(dsn)}
+	if cfg.InMemory {
+		opts = append(opts, comfylite3.WithMemory())
+	} else {
+		opts = append(opts, comfylite3.WithPath(cfg.Path))
+	}
+	comfy, err := comfylite3.New(opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening %s: %w", dsn, err)
+	}
+	if err := InstallFunctions(context.Background(), comfy); err != nil {
+		comfy.Close()
+		return nil, nil, err
+	}
+	var dbOpts []comfylite3.OpenDBOption
+	if cfg.ForeignKeys {
+		dbOpts = append(dbOpts, comfylite3.WithForeignKeys())
+	}
+	db := openDB(comfy, nil, cipher, dbOpts...)
+	if cfg.ForeignKeys {
+		if err := AssertForeignKeysEnabled(db); err != nil {
+			db.Close()
+			comfy.Close()
+			return nil, nil, err
+		}
+	}
+	var drv dialect.Driver = sql.OpenDB(dialect.SQLite, db)
+	if cfg.SerializeWrites {
+		// Writers on the same database share their lock.
+		database := dsn
+		if !cfg.InMemory {
+			if database, err = filepath.Abs(cfg.Path); err != nil {
+				drv.Close()
+				comfy.Close()
+				return nil, nil, err
+			}
+		}
+		drv = driver.NewSerializedWriter(drv, database, cfg.BusyTimeout)
+	}
+	return drv, comfy, nil
+}
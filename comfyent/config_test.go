@@ -0,0 +1,127 @@
+package comfyent_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestOpenPragmas(t *testing.T) {
+	client, comfy, err := comfyent.Open(comfyent.Config{
+		Path:        filepath.Join(t.TempDir(), "test.db"),
+		ForeignKeys: true,
+		JournalMode: "wal",
+		BusyTimeout: 3 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	defer client.Close()
+	ctx := context.Background()
+
+	for pragma, want := range map[string]string{
+		"journal_mode": "wal",
+		"foreign_keys": "1",
+		"busy_timeout": "3000",
+	} {
+		var got string
+		if err := comfy.QueryRowContext(ctx, "PRAGMA "+pragma+";").Scan(&got); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.EqualFold(got, want) {
+			t.Fatalf("got %s %s, want %s", pragma, got, want)
+		}
+	}
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpenSharedCache(t *testing.T) {
+	cfg := comfyent.Config{Path: t.Name(), InMemory: true, SharedCache: true, ForeignKeys: true}
+	first, firstComfy, err := comfyent.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer firstComfy.Close()
+	defer first.Close()
+	second, secondComfy, err := comfyent.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secondComfy.Close()
+	defer second.Close()
+	ctx := context.Background()
+
+	if err := first.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	first.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").ExecX(ctx)
+	if n := second.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users through the second handle, want the shared database's 1", n)
+	}
+
+	// Without a name, every in-memory database is private.
+	private, privateComfy, err := comfyent.Open(comfyent.Config{InMemory: true, SharedCache: true, ForeignKeys: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer privateComfy.Close()
+	defer private.Close()
+	if err := private.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := private.User.Query().CountX(ctx); n != 0 {
+		t.Fatalf("got %d users in an unnamed database, want 0", n)
+	}
+}
+
+func TestNewDebugClient(t *testing.T) {
+	for _, withArgs := range []bool{false, true} {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		var opts []comfyent.DebugOption
+		if withArgs {
+			opts = append(opts, comfyent.WithSQLArgs())
+		}
+		client, comfy, err := comfyent.NewDebugClient(comfyent.Config{InMemory: true, ForeignKeys: true}, logger, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := context.Background()
+		if err := client.Schema.Create(ctx); err != nil {
+			t.Fatal(err)
+		}
+		client.User.Create().SetName("a").SetAge(30).SetEmail("secret@example.com").ExecX(ctx)
+		client.Close()
+		comfy.Close()
+
+		var insert map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				t.Fatalf("decoding log line %q: %v", line, err)
+			}
+			if query, _ := entry["query"].(string); strings.HasPrefix(query, "INSERT INTO `users`") {
+				insert = entry
+			}
+		}
+		if insert == nil {
+			t.Fatalf("got logs %s, want the user insert", logs.String())
+		}
+		if insert["msg"] != "sql" || insert["level"] != "DEBUG" || insert["duration"] == nil {
+			t.Fatalf("got log entry %v, want a debug sql entry with the duration", insert)
+		}
+		if leaked := strings.Contains(logs.String(), "secret@example.com"); leaked != withArgs {
+			t.Fatalf("email logged: %v, want %v with WithSQLArgs %v", leaked, withArgs, withArgs)
+		}
+	}
+}
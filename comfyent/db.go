@@ -0,0 +1,392 @@
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/ent/schematype"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+	"github.com/mattn/go-sqlite3"
+)
+
+// OpenDB is a drop-in replacement for comfylite3.OpenDB.
+//
+// Statements still go through the comfylite3 scheduler, but rows are scanned into *any so
+// NULL columns (optional fields, edges) load properly, and transactions are real SQLite
+// transactions that can be rolled back.
+func OpenDB(comfy *comfylite3.ComfyDB, opts ...comfylite3.OpenDBOption) *sql.DB {
+	return OpenDBWithInit(comfy, nil, opts...)
+}
+
+// OpenDBWithInit is like OpenDB, but runs initSQL (typically pragmas such as
+// "PRAGMA foreign_keys = ON" or "PRAGMA busy_timeout = 5000") before the pool hands out its
+// first connection. comfylite3 runs every statement on a single shared connection, so
+// settings applied once hold for all the connections of the pool, recycled ones included.
+// If initSQL fails, opening the connection fails and it is run again on the next attempt.
+func OpenDBWithInit(comfy *comfylite3.ComfyDB, initSQL []string, opts ...comfylite3.OpenDBOption) *sql.DB {
+	return openDB(comfy, initSQL, nil, opts...)
+}
+
+// encryptedColumns are the columns of the EncryptedString fields, the only ones encrypted and
+// decrypted.
+var encryptedColumns = map[string]bool{
+	user.FieldEmail: true,
+}
+
+// openDB is like OpenDBWithInit, with the connections encrypting and decrypting the values of
+// EncryptedString fields with cipher, if not nil.
+func openDB(comfy *comfylite3.ComfyDB, initSQL []string, cipher *schematype.Cipher, opts ...comfylite3.OpenDBOption) *sql.DB {
+	// Let comfylite3 apply its own options (e.g. the foreign_keys pragma) on the shared connection.
+	comfylite3.OpenDB(comfy, opts...).Close()
+	return sql.OpenDB(&connector{comfy: comfy, inflight: inflightOf(comfy), init: initSQL, cipher: cipher})
+}
+
+// connector hands out connections backed by a ComfyDB.
+type connector struct {
+	comfy    *comfylite3.ComfyDB
+	inflight *inflight
+	init     []string
+	cipher   *schematype.Cipher
+
+	initMu   sync.Mutex
+	initDone bool
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	if err := c.runInit(ctx); err != nil {
+		return nil, err
+	}
+	return &conn{comfy: c.comfy, inflight: c.inflight, cipher: c.cipher}, nil
+}
+
+// runInit runs the init statements, unless they already succeeded once.
+func (c *connector) runInit(ctx context.Context) error {
+	c.initMu.Lock()
+	defer c.initMu.Unlock()
+	if c.initDone {
+		return nil
+	}
+	for _, query := range c.init {
+		if _, err := c.comfy.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("initializing connection with %q: %w", query, err)
+		}
+	}
+	c.initDone = true
+	return nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c
+}
+
+func (c *connector) Open(string) (driver.Conn, error) {
+	return c.Connect(context.Background())
+}
+
+// conn runs statements through the comfylite3 scheduler, or on the
+// underlying *sql.Tx while a transaction is open.
+type conn struct {
+	comfy    *comfylite3.ComfyDB
+	inflight *inflight
+	tx       *sql.Tx
+	cipher   *schematype.Cipher
+	// stmts are the statements prepared within tx, see withPrepared.
+	stmts map[string]*sql.Stmt
+}
+
+type preparedKey struct{}
+
+// withPrepared returns a context whose statements, when run within a transaction, are prepared
+// once per transaction and query, then reused. comfylite3 queues statements out of
+// transactions for its scheduler, but those within one go straight to SQLite, which then skips
+// parsing and planning them again.
+func withPrepared(parent context.Context) context.Context {
+	return context.WithValue(parent, preparedKey{}, true)
+}
+
+// prepared returns query prepared within the transaction if ctx asks for it, nil otherwise.
+func (c *conn) prepared(ctx context.Context, query string) (*sql.Stmt, error) {
+	if ok, _ := ctx.Value(preparedKey{}).(bool); !ok {
+		return nil, nil
+	}
+	if st, ok := c.stmts[query]; ok {
+		return st, nil
+	}
+	// Statements prepared on a transaction are closed with it.
+	st, err := c.tx.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if c.stmts == nil {
+		c.stmts = make(map[string]*sql.Stmt)
+	}
+	c.stmts[query] = st
+	return st, nil
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	if c.tx != nil {
+		err := c.tx.Rollback()
+		c.tx, c.stmts = nil, nil
+		c.inflight.release()
+		return err
+	}
+	return nil
+}
+
+// Ping checks the ComfyDB is still serving queries. comfylite3 waits for its scheduler
+// regardless of ctx, so the wait is abandoned when ctx is done.
+func (c *conn) Ping(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- c.comfy.PingContext(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	// The transaction counts as in flight until it is committed or rolled back.
+	if err := c.inflight.acquire(false); err != nil {
+		return nil, err
+	}
+	tx, err := c.comfy.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.IsolationLevel(opts.Isolation),
+		ReadOnly:  opts.ReadOnly,
+	})
+	if err != nil {
+		c.inflight.release()
+		return nil, err
+	}
+	c.tx = tx
+	return &connTx{conn: c}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.inflight.acquire(c.tx != nil); err != nil {
+		return nil, err
+	}
+	defer c.inflight.release()
+	c.encryptArgs(query, args)
+	if c.tx != nil {
+		st, err := c.prepared(ctx, query)
+		switch {
+		case err != nil:
+			return nil, err
+		case st != nil:
+			return st.ExecContext(ctx, namedValues(args)...)
+		}
+		return c.tx.ExecContext(ctx, query, namedValues(args)...)
+	}
+	res, err := c.comfy.ExecContext(ctx, query, namedValues(args)...)
+	return res, badConn(err)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	// The query counts as in flight until its rows are closed.
+	if err := c.inflight.acquire(c.tx != nil); err != nil {
+		return nil, err
+	}
+	c.encryptArgs(query, args)
+	var (
+		r   *sql.Rows
+		err error
+	)
+	if c.tx != nil {
+		var st *sql.Stmt
+		if st, err = c.prepared(ctx, query); st != nil {
+			r, err = st.QueryContext(ctx, namedValues(args)...)
+		} else if err == nil {
+			r, err = c.tx.QueryContext(ctx, query, namedValues(args)...)
+		}
+	} else {
+		r, err = c.comfy.QueryContext(ctx, query, namedValues(args)...)
+		err = badConn(err)
+	}
+	if err != nil {
+		c.inflight.release()
+		return nil, err
+	}
+	return &rows{rows: r, inflight: c.inflight, cipher: c.cipher}, nil
+}
+
+// badConnError is an SQLITE_IOERR also reported as driver.ErrBadConn, for database/sql to drop
+// the connection and run the statement again on another one. SQLite rolls back the statements
+// failing with an I/O error, so this is only safe outside of transactions.
+type badConnError struct {
+	err error
+}
+
+func (e *badConnError) Error() string {
+	return e.err.Error()
+}
+
+func (e *badConnError) Unwrap() []error {
+	return []error{e.err, driver.ErrBadConn}
+}
+
+// badConn returns err as a *badConnError if it is an SQLITE_IOERR.
+func badConn(err error) error {
+	var serr sqlite3.Error
+	if errors.As(err, &serr) && serr.Code == sqlite3.ErrIoErr {
+		return &badConnError{err: err}
+	}
+	return err
+}
+
+// connTx ends the transaction opened on its conn.
+type connTx struct {
+	conn *conn
+}
+
+func (t *connTx) Commit() error {
+	tx := t.conn.tx
+	t.conn.tx, t.conn.stmts = nil, nil
+	defer t.conn.inflight.release()
+	return tx.Commit()
+}
+
+func (t *connTx) Rollback() error {
+	tx := t.conn.tx
+	t.conn.tx, t.conn.stmts = nil, nil
+	defer t.conn.inflight.release()
+	return tx.Rollback()
+}
+
+// stmt is a lazily "prepared" statement that executes through its conn.
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), values(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), values(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+// rows adapts *sql.Rows to driver.Rows.
+type rows struct {
+	rows     *sql.Rows
+	inflight *inflight
+	cipher   *schematype.Cipher
+	// encrypted tells the columns of encryptedColumns apart, once a row was read with a cipher.
+	encrypted []bool
+	closed    bool
+}
+
+func (r *rows) Columns() []string {
+	cols, _ := r.rows.Columns()
+	return cols
+}
+
+func (r *rows) Close() error {
+	if !r.closed {
+		r.closed = true
+		defer r.inflight.release()
+	}
+	return r.rows.Close()
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	vals := make([]any, len(dest))
+	ptrs := make([]any, len(dest))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	if err := r.rows.Scan(ptrs...); err != nil {
+		return err
+	}
+	if r.cipher != nil && r.encrypted == nil {
+		r.encrypted = make([]bool, len(dest))
+		for i, col := range r.Columns() {
+			r.encrypted[i] = encryptedColumns[col]
+		}
+	}
+	for i, v := range vals {
+		dest[i] = v
+		if r.cipher != nil && r.encrypted[i] {
+			dest[i] = r.decrypt(v)
+		}
+	}
+	return nil
+}
+
+// decrypt returns v decrypted if it is a text encrypted with the cipher of r.
+func (r *rows) decrypt(v any) any {
+	switch t := v.(type) {
+	case string:
+		if pt, ok := r.cipher.Decrypt(t); ok {
+			return pt
+		}
+	case []byte:
+		if pt, ok := r.cipher.Decrypt(string(t)); ok {
+			return []byte(pt)
+		}
+	}
+	return v
+}
+
+// namedValues converts driver arguments back into database/sql arguments.
+func namedValues(args []driver.NamedValue) []any {
+	vals := make([]any, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			vals[i] = sql.Named(arg.Name, arg.Value)
+			continue
+		}
+		vals[i] = arg.Value
+	}
+	return vals
+}
+
+// values converts positional driver values into named values.
+func values(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
@@ -0,0 +1,38 @@
+package comfyent_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestOpenDBWithInit(t *testing.T) {
+	db := comfyent.OpenDBWithInit(newFileComfy(t), []string{"PRAGMA foreign_keys = ON;"})
+	defer db.Close()
+	ctx := context.Background()
+
+	// Hold the connections at once, so the pool opens a new one each time.
+	conns := make([]*sql.Conn, 4)
+	for i := range conns {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		conns[i] = conn
+	}
+	for i, conn := range conns {
+		var enabled int
+		if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys;").Scan(&enabled); err != nil {
+			t.Fatal(err)
+		}
+		if enabled != 1 {
+			t.Fatalf("got foreign_keys %d on connection %d, want 1", enabled, i)
+		}
+	}
+	if n := db.Stats().OpenConnections; n != len(conns) {
+		t.Fatalf("got %d open connections, want %d", n, len(conns))
+	}
+}
@@ -0,0 +1,68 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/predicate"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// DeleteOptions configures DeleteUsersWhere.
+type DeleteOptions struct {
+	// DryRun previews the deletion: the users that would be deleted are returned, and none is.
+	DryRun bool
+}
+
+// DeleteResult holds the users DeleteUsersWhere deleted, or would delete on a dry run.
+type DeleteResult struct {
+	// IDs are the IDs of the users, in ascending order.
+	IDs   []int
+	Count int
+}
+
+// DeleteUsersWhere deletes the users matching all the given predicates and returns them.
+// Users are soft-deleted unless ctx was derived from schema.SkipSoftDelete; with no predicate
+// every user is deleted.
+//
+// The users are listed, then deleted in a single statement, within one transaction, so the
+// result holds exactly the users deleted. A dry run lists them the same way and stops there.
+func DeleteUsersWhere(ctx context.Context, client *ent.Client, opts DeleteOptions, preds ...predicate.User) (res DeleteResult, err error) {
+	if opts.DryRun {
+		ids, err := usersToDelete(ctx, client.User, preds)
+		if err != nil {
+			return DeleteResult{}, fmt.Errorf("previewing user deletion: %w", err)
+		}
+		return DeleteResult{IDs: ids, Count: len(ids)}, nil
+	}
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return DeleteResult{}, fmt.Errorf("deleting users: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			err = fmt.Errorf("deleting users: %w", err)
+		}
+	}()
+	ids, err := usersToDelete(ctx, tx.User, preds)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+	n, err := tx.User.Delete().Where(preds...).Exec(ctx)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return DeleteResult{}, err
+	}
+	return DeleteResult{IDs: ids, Count: n}, nil
+}
+
+// usersToDelete returns the IDs of the users a delete of the users matching preds affects.
+// Queries skip soft-deleted users exactly like soft deletes do, and include them when
+// SkipSoftDelete makes the deletion permanent.
+func usersToDelete(ctx context.Context, users *ent.UserClient, preds []predicate.User) ([]int, error) {
+	return users.Query().Where(preds...).Order(ent.Asc(user.FieldID)).IDs(ctx)
+}
@@ -0,0 +1,82 @@
+package comfyent_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestDeleteUsersWhere(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	for i := range 10 {
+		domain := "example.com"
+		if i%2 == 0 {
+			domain = "spam.test"
+		}
+		client.User.Create().SetName(fmt.Sprintf("user%d", i)).SetAge(30).SetEmail(fmt.Sprintf("user%d@%s", i, domain)).ExecX(ctx)
+	}
+
+	res, err := comfyent.DeleteUsersWhere(ctx, client, comfyent.DeleteOptions{}, user.EmailHasSuffix("@spam.test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Count != 5 || len(res.IDs) != 5 {
+		t.Fatalf("deleted %d users %v, want 5", res.Count, res.IDs)
+	}
+	for _, id := range res.IDs {
+		if u := client.User.GetX(schema.SkipSoftDelete(ctx), id); u.DeletedAt == nil {
+			t.Fatalf("got user %d (%s) in the result, not deleted", id, u.Email)
+		}
+	}
+	if left := client.User.Query().Where(user.EmailHasSuffix("@example.com")).CountX(ctx); left != 5 {
+		t.Fatalf("got %d users left, want the 5 others", left)
+	}
+	// The deleted users were soft-deleted.
+	if all := client.User.Query().CountX(schema.SkipSoftDelete(ctx)); all != 10 {
+		t.Fatalf("got %d users including deleted ones, want 10", all)
+	}
+}
+
+func TestDeleteUsersWhereDryRun(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	users := createManyUsers(t, client, 10)
+
+	// createManyUsers gives users 0 to 4 ages 20 to 24.
+	preview, err := comfyent.DeleteUsersWhere(ctx, client, comfyent.DeleteOptions{DryRun: true}, user.AgeLT(25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Count != 5 || len(preview.IDs) != 5 {
+		t.Fatalf("got %d users %v, want the 5 users under 25", preview.Count, preview.IDs)
+	}
+	for i, id := range preview.IDs {
+		if id != users[i].ID {
+			t.Fatalf("got user %d at %d, want %d", id, i, users[i].ID)
+		}
+	}
+	if n := client.User.Query().CountX(ctx); n != 10 {
+		t.Fatalf("got %d users after a dry run, want all 10", n)
+	}
+	if n := client.User.Query().CountX(schema.SkipSoftDelete(ctx)); n != 10 {
+		t.Fatalf("got %d users including deleted ones after a dry run, want 10", n)
+	}
+
+	// The preview matches what is then deleted.
+	res, err := comfyent.DeleteUsersWhere(ctx, client, comfyent.DeleteOptions{}, user.AgeLT(25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Count != preview.Count || !slices.Equal(res.IDs, preview.IDs) {
+		t.Fatalf("deleted %d users %v, want the %d previewed %v", res.Count, res.IDs, preview.Count, preview.IDs)
+	}
+}
@@ -0,0 +1,42 @@
+package driver
+
+import (
+	"context"
+	"errors"
+
+	"entgo.io/ent/dialect"
+)
+
+// ErrCaptured is returned, instead of running it, for a query captured by a capture driver.
+var ErrCaptured = errors.New("query captured, not run")
+
+// Captured is the SQL of a statement captured by a capture driver.
+type Captured struct {
+	Query string
+	Args  []any
+}
+
+type captureKey struct{}
+
+// WithCapture returns a context making a capture driver record, rather than run, its next query
+// into the returned Captured.
+func WithCapture(parent context.Context) (context.Context, *Captured) {
+	c := &Captured{}
+	return context.WithValue(parent, captureKey{}, c), c
+}
+
+// NewCaptureDriver returns a driver handing the SQL built by ent to whoever asked for it with
+// WithCapture. Other statements, and every statement run with a plain context, go to inner.
+func NewCaptureDriver(inner dialect.Driver) *Driver {
+	return Wrap(inner, func(next Handler) Handler {
+		return func(ctx context.Context, stmt Statement, v any) error {
+			c, ok := ctx.Value(captureKey{}).(*Captured)
+			if !ok || stmt.Op != OpQuery {
+				return next(ctx, stmt, v)
+			}
+			c.Query = stmt.Query
+			c.Args, _ = stmt.Args.([]any)
+			return ErrCaptured
+		}
+	})
+}
@@ -0,0 +1,197 @@
+// Package driver provides the plumbing to decorate the dialect.Driver handed to ent,
+// so every statement, including those run within transactions, can be observed or altered.
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// Op is the driver operation a statement goes through.
+type Op string
+
+// Driver operations.
+const (
+	OpExec  Op = "exec"
+	OpQuery Op = "query"
+)
+
+// Statement is a single Exec or Query sent to the driver.
+type Statement struct {
+	Op    Op
+	Query string
+	Args  any
+	// InTx reports whether the statement runs within a transaction.
+	InTx bool
+}
+
+// Handler runs a statement and scans its result into v.
+type Handler func(ctx context.Context, stmt Statement, v any) error
+
+// Middleware decorates a Handler.
+type Middleware func(next Handler) Handler
+
+// Driver is a dialect.Driver sending every statement through a Middleware.
+type Driver struct {
+	dialect.Driver
+	mw Middleware
+	h  Handler
+}
+
+// Wrap returns a driver running the statements of inner, and of its transactions, through mw.
+func Wrap(inner dialect.Driver, mw Middleware) *Driver {
+	return &Driver{
+		Driver: inner,
+		mw:     mw,
+		h:      mw(handler(inner)),
+	}
+}
+
+// Exec runs an Exec statement through the middleware.
+func (d *Driver) Exec(ctx context.Context, query string, args, v any) error {
+	return d.h(ctx, Statement{Op: OpExec, Query: query, Args: args}, v)
+}
+
+// Query runs a Query statement through the middleware.
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	return d.h(ctx, Statement{Op: OpQuery, Query: query, Args: args}, v)
+}
+
+// ExecContext runs an Exec statement through the middleware, for ent's ExecContext.
+func (d *Driver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var res sql.Result
+	if err := d.Exec(ctx, query, args, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// QueryContext runs a Query statement through the middleware, for ent's QueryContext.
+func (d *Driver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return queryContext(ctx, d.Query, query, args)
+}
+
+// Tx starts a transaction whose statements also go through the middleware.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return d.wrapTx(tx), nil
+}
+
+// BeginTx starts a transaction with the given options if the underlying driver supports it.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.BeginTx is not supported")
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return d.wrapTx(tx), nil
+}
+
+func (d *Driver) wrapTx(tx dialect.Tx) *Tx {
+	return &Tx{Tx: tx, h: d.mw(handler(tx))}
+}
+
+// Tx is a dialect.Tx sending its statements through the middleware of the driver that started it.
+type Tx struct {
+	dialect.Tx
+	h Handler
+}
+
+// Exec runs an Exec statement through the middleware.
+func (t *Tx) Exec(ctx context.Context, query string, args, v any) error {
+	return t.h(ctx, Statement{Op: OpExec, Query: query, Args: args, InTx: true}, v)
+}
+
+// Query runs a Query statement through the middleware.
+func (t *Tx) Query(ctx context.Context, query string, args, v any) error {
+	return t.h(ctx, Statement{Op: OpQuery, Query: query, Args: args, InTx: true}, v)
+}
+
+// ExecContext runs an Exec statement through the middleware, for ent's ExecContext.
+func (t *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var res sql.Result
+	if err := t.Exec(ctx, query, args, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// QueryContext runs a Query statement through the middleware, for ent's QueryContext.
+func (t *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return queryContext(ctx, t.Query, query, args)
+}
+
+// queryContext runs query with query and unwraps the *sql.Rows ent's driver scanned into.
+func queryContext(ctx context.Context, query func(context.Context, string, any, any) error, q string, args []any) (*sql.Rows, error) {
+	var rows entsql.Rows
+	if err := query(ctx, q, args, &rows); err != nil {
+		return nil, err
+	}
+	r, ok := rows.ColumnScanner.(*sql.Rows)
+	if !ok {
+		if rows.ColumnScanner != nil {
+			rows.Close()
+		}
+		return nil, fmt.Errorf("driver.QueryContext is not supported")
+	}
+	return r, nil
+}
+
+// handler returns the Handler running statements on eq.
+func handler(eq dialect.ExecQuerier) Handler {
+	return func(ctx context.Context, stmt Statement, v any) error {
+		if stmt.Op == OpQuery {
+			return eq.Query(ctx, stmt.Query, stmt.Args, v)
+		}
+		return eq.Exec(ctx, stmt.Query, stmt.Args, v)
+	}
+}
+
+// Keyword returns the leading SQL keyword of query in lower case, e.g. "select"
+// or "insert", skipping whitespace and comments.
+func Keyword(query string) string {
+	kw, _ := splitKeyword(query)
+	return kw
+}
+
+// splitKeyword returns the leading SQL keyword of query in lower case, and what follows it.
+func splitKeyword(query string) (kw, rest string) {
+	for {
+		query = strings.TrimLeftFunc(query, unicode.IsSpace)
+		switch {
+		case strings.HasPrefix(query, "--"):
+			if i := strings.IndexByte(query, '\n'); i >= 0 {
+				query = query[i+1:]
+				continue
+			}
+			return "", ""
+		case strings.HasPrefix(query, "/*"):
+			if i := strings.Index(query, "*/"); i >= 0 {
+				query = query[i+2:]
+				continue
+			}
+			return "", ""
+		}
+		end := strings.IndexFunc(query, func(r rune) bool {
+			return !unicode.IsLetter(r)
+		})
+		if end < 0 {
+			end = len(query)
+		}
+		return strings.ToLower(query[:end]), query[end:]
+	}
+}
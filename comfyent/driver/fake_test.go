@@ -0,0 +1,71 @@
+package driver_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// fakeDriver is a dialect.Driver recording the statements it gets instead of running them.
+// Statements take delay, and fail with what fail returns if set.
+type fakeDriver struct {
+	delay time.Duration
+	fail  func(query string) error
+
+	mu      sync.Mutex
+	queries []string
+}
+
+func (d *fakeDriver) Exec(ctx context.Context, query string, args, v any) error {
+	return d.run(ctx, query)
+}
+
+func (d *fakeDriver) Query(ctx context.Context, query string, args, v any) error {
+	return d.run(ctx, query)
+}
+
+func (d *fakeDriver) run(ctx context.Context, query string) error {
+	d.mu.Lock()
+	d.queries = append(d.queries, query)
+	d.mu.Unlock()
+	if d.delay > 0 {
+		select {
+		case <-time.After(d.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if d.fail != nil {
+		return d.fail(query)
+	}
+	return nil
+}
+
+// Queries returns the statements received so far.
+func (d *fakeDriver) Queries() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]string(nil), d.queries...)
+}
+
+func (d *fakeDriver) Tx(context.Context) (dialect.Tx, error) {
+	return fakeTx{d}, nil
+}
+
+func (d *fakeDriver) Close() error {
+	return nil
+}
+
+func (d *fakeDriver) Dialect() string {
+	return dialect.SQLite
+}
+
+// fakeTx is a transaction of a fakeDriver, running its statements alike.
+type fakeTx struct {
+	*fakeDriver
+}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
@@ -0,0 +1,139 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+	"github.com/davidroman0O/comfylite3-ent/internal/implicittx"
+)
+
+// ImplicitTx is a transaction an ImplicitTxDriver begins on the first statement run with the
+// context returned by WithImplicitTx, and runs the following ones within. It lets a hook make
+// the mutation it wraps atomic with its own writes when the mutation doesn't run within a
+// transaction, as an ent builder keeps running on the driver it was created with.
+type ImplicitTx = implicittx.Tx
+
+// WithImplicitTx returns a context whose statements run within the returned ImplicitTx, on an
+// ImplicitTxDriver. On other drivers they run as they come. The caller ends it with Commit or
+// Rollback.
+func WithImplicitTx(parent context.Context) (context.Context, *ImplicitTx) {
+	return implicittx.With(parent)
+}
+
+// InImplicitTx reports whether ctx comes from WithImplicitTx.
+func InImplicitTx(ctx context.Context) bool {
+	_, ok := implicittx.From(ctx)
+	return ok
+}
+
+// ImplicitTxDriver is a driver running the statements of an ImplicitTx within it.
+type ImplicitTxDriver struct {
+	dialect.Driver
+}
+
+// NewImplicitTxDriver returns a driver running the statements of inner within the ImplicitTx of
+// their context, if any. It goes outermost, so the transaction goes through every other wrapper.
+func NewImplicitTxDriver(inner dialect.Driver) *ImplicitTxDriver {
+	return &ImplicitTxDriver{Driver: inner}
+}
+
+// execQuerier returns what runs the statement of ctx.
+func (d *ImplicitTxDriver) execQuerier(ctx context.Context) (dialect.ExecQuerier, error) {
+	it, ok := implicittx.From(ctx)
+	if !ok {
+		return d.Driver, nil
+	}
+	tx, ok, err := it.Open(ctx, d.Driver)
+	switch {
+	case err != nil:
+		return nil, err
+	case !ok:
+		return d.Driver, nil
+	}
+	return tx, nil
+}
+
+// Exec runs an Exec statement, within the ImplicitTx of ctx if any.
+func (d *ImplicitTxDriver) Exec(ctx context.Context, query string, args, v any) error {
+	eq, err := d.execQuerier(ctx)
+	if err != nil {
+		return err
+	}
+	return eq.Exec(ctx, query, args, v)
+}
+
+// Query runs a Query statement, within the ImplicitTx of ctx if any.
+func (d *ImplicitTxDriver) Query(ctx context.Context, query string, args, v any) error {
+	eq, err := d.execQuerier(ctx)
+	if err != nil {
+		return err
+	}
+	return eq.Query(ctx, query, args, v)
+}
+
+// ExecContext runs an Exec statement, for ent's ExecContext.
+func (d *ImplicitTxDriver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var res sql.Result
+	if err := d.Exec(ctx, query, args, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// QueryContext runs a Query statement, for ent's QueryContext.
+func (d *ImplicitTxDriver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return queryContext(ctx, d.Query, query, args)
+}
+
+// Tx starts a transaction, or returns the ImplicitTx of ctx if any, which only its owner ends.
+func (d *ImplicitTxDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.BeginTx(ctx, nil)
+}
+
+// BeginTx is like Tx, with the given options if the underlying driver supports them. The
+// options don't apply to an ImplicitTx.
+func (d *ImplicitTxDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	if it, ok := implicittx.From(ctx); ok {
+		tx, ok, err := it.Open(ctx, d.Driver)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return joinedTx{Tx: tx}, nil
+		}
+	}
+	if opts == nil {
+		return d.Driver.Tx(ctx)
+	}
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.BeginTx is not supported")
+	}
+	return drv.BeginTx(ctx, opts)
+}
+
+// joinedTx is an ImplicitTx handed to those starting a transaction within it, which can't end it.
+type joinedTx struct {
+	dialect.Tx
+}
+
+func (joinedTx) Commit() error   { return nil }
+func (joinedTx) Rollback() error { return nil }
+
+// ExecContext runs an Exec statement within the transaction, for ent's ExecContext.
+func (t joinedTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var res sql.Result
+	if err := t.Exec(ctx, query, args, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// QueryContext runs a Query statement within the transaction, for ent's QueryContext.
+func (t joinedTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return queryContext(ctx, t.Query, query, args)
+}
@@ -0,0 +1,28 @@
+package driver
+
+import (
+	"errors"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/mattn/go-sqlite3"
+)
+
+// NewIOErrorRetryDriver returns a driver retrying the statements of inner failing with
+// SQLITE_IOERR, up to attempts times in total, waiting backoff between two attempts, so a
+// transient storage failure doesn't surface to the caller. Other errors are returned right away.
+//
+// SQLite rolls a statement back when it hits an I/O error, so running it again is safe. The
+// connections of comfyent.OpenDB report it as driver.ErrBadConn too, so database/sql drops
+// the connection and runs the statement on another one before the driver waits and retries.
+// Statements within a transaction aren't retried: the transaction may have been rolled back
+// with them, it has to be retried as a whole, see tx.WithTxRetry.
+func NewIOErrorRetryDriver(inner dialect.Driver, attempts int, backoff time.Duration) *Driver {
+	policy := RetryPolicy{Attempts: attempts, Backoff: backoff, MaxBackoff: backoff}
+	return newRetryDriver(inner, policy, isIOError)
+}
+
+func isIOError(err error) bool {
+	var serr sqlite3.Error
+	return errors.As(err, &serr) && serr.Code == sqlite3.ErrIoErr
+}
@@ -0,0 +1,62 @@
+package driver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/mattn/go-sqlite3"
+)
+
+// failFirst returns a fail func for a fakeDriver returning err for the first n statements.
+func failFirst(n int, err error) func(string) error {
+	return func(string) error {
+		if n > 0 {
+			n--
+			return err
+		}
+		return nil
+	}
+}
+
+func TestIOErrorRetryDriver(t *testing.T) {
+	ctx := context.Background()
+	ioErr := sqlite3.Error{Code: sqlite3.ErrIoErr}
+
+	inner := &fakeDriver{fail: failFirst(1, ioErr)}
+	drv := driver.NewIOErrorRetryDriver(inner, 3, time.Millisecond)
+	if err := drv.Exec(ctx, "UPDATE users SET age = 1", []any{}, nil); err != nil {
+		t.Fatalf("got %v, want the retry to succeed", err)
+	}
+	if n := len(inner.Queries()); n != 2 {
+		t.Fatalf("ran the statement %d times, want 2", n)
+	}
+
+	// Other errors aren't retried.
+	errBoom := errors.New("boom")
+	inner = &fakeDriver{fail: failFirst(1, errBoom)}
+	drv = driver.NewIOErrorRetryDriver(inner, 3, time.Millisecond)
+	if err := drv.Exec(ctx, "UPDATE users SET age = 1", []any{}, nil); !errors.Is(err, errBoom) {
+		t.Fatalf("got %v, want %v", err, errBoom)
+	}
+	if n := len(inner.Queries()); n != 1 {
+		t.Fatalf("ran the statement %d times, want 1", n)
+	}
+
+	// Neither are statements within transactions.
+	inner = &fakeDriver{fail: failFirst(1, ioErr)}
+	drv = driver.NewIOErrorRetryDriver(inner, 3, time.Millisecond)
+	tx, err := drv.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var serr sqlite3.Error
+	if err := tx.Exec(ctx, "UPDATE users SET age = 1", []any{}, nil); !errors.As(err, &serr) || serr.Code != sqlite3.ErrIoErr {
+		t.Fatalf("got %v within a transaction, want the I/O error", err)
+	}
+	if n := len(inner.Queries()); n != 1 {
+		t.Fatalf("ran the statement %d times within a transaction, want 1", n)
+	}
+}
@@ -0,0 +1,107 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/mattn/go-sqlite3"
+)
+
+// LockStats reports the lock contention seen by a LockDriver.
+type LockStats struct {
+	// Busy counts the statements that failed with SQLITE_BUSY or SQLITE_LOCKED.
+	Busy int64
+	// Consecutive is the length of the current streak of busy errors of the connection that
+	// last got one, reset by any of its statements succeeding.
+	Consecutive int
+	// Warnings counts the streaks that crossed the threshold.
+	Warnings int64
+	// LastQuery is the last statement that failed with a busy error.
+	LastQuery string
+}
+
+// LockDriver is a dialect.Driver watching for runs of "database is locked" errors.
+type LockDriver struct {
+	*Driver
+	threshold int
+	window    time.Duration
+	logf      func(format string, args ...any)
+
+	mu    sync.Mutex
+	stats LockStats
+	last  *streak
+}
+
+// streak is a run of busy errors on a connection: the pool of the driver, or a transaction.
+type streak struct {
+	n     int
+	start time.Time
+}
+
+// NewLockContentionDriver returns a driver calling logf when threshold statements of inner in
+// a row fail with a busy error within window on the same connection, which points at writers
+// competing for the file. Statements outside of transactions share the connection of the
+// driver, a single one with comfylite3, each transaction has its own.
+func NewLockContentionDriver(inner dialect.Driver, threshold int, window time.Duration, logf func(format string, args ...any)) *LockDriver {
+	d := &LockDriver{threshold: threshold, window: window, logf: logf}
+	d.Driver = Wrap(inner, d.watch)
+	return d
+}
+
+// LockStats returns a snapshot of the contention seen so far.
+func (d *LockDriver) LockStats() LockStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stats := d.stats
+	if d.last != nil {
+		stats.Consecutive = d.last.n
+	}
+	return stats
+}
+
+// watch is called for the driver and for each of its transactions, which get their own streak.
+func (d *LockDriver) watch(next Handler) Handler {
+	s := &streak{}
+	return func(ctx context.Context, stmt Statement, v any) error {
+		err := next(ctx, stmt, v)
+		busy := isBusy(err)
+		if !busy && err != nil {
+			// Unrelated failures neither extend nor break the streak.
+			return err
+		}
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if !busy {
+			s.n = 0
+			return nil
+		}
+		now := time.Now()
+		if s.n == 0 || now.Sub(s.start) > d.window {
+			s.n = 0
+			s.start = now
+		}
+		s.n++
+		d.last = s
+		d.stats.Busy++
+		d.stats.LastQuery = stmt.Query
+		if s.n == d.threshold {
+			d.stats.Warnings++
+			d.logf("comfyent: %d statements in a row failed on a locked database within %s, last: %s; "+
+				"consider enabling WAL (comfyent.OpenWithWAL) or increasing busy_timeout (comfyent.WithBusyTimeout)",
+				s.n, now.Sub(s.start).Round(time.Millisecond), stmt.Query)
+		}
+		return err
+	}
+}
+
+// isBusy reports whether err is a SQLITE_BUSY or SQLITE_LOCKED error.
+func isBusy(err error) bool {
+	var serr sqlite3.Error
+	if !errors.As(err, &serr) {
+		return false
+	}
+	return serr.Code == sqlite3.ErrBusy || serr.Code == sqlite3.ErrLocked
+}
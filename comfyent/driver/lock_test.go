@@ -0,0 +1,63 @@
+package driver_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestLockContentionDriver(t *testing.T) {
+	inner := &fakeDriver{fail: func(string) error {
+		return sqlite3.Error{Code: sqlite3.ErrBusy}
+	}}
+	var (
+		mu       sync.Mutex
+		warnings int
+	)
+	drv := driver.NewLockContentionDriver(inner, 3, time.Minute, func(string, ...any) {
+		mu.Lock()
+		warnings++
+		mu.Unlock()
+	})
+	ctx := context.Background()
+
+	// Two goroutines, each on a transaction of its own, hit busy errors in turns.
+	var wg sync.WaitGroup
+	turns := []chan struct{}{make(chan struct{}, 1), make(chan struct{}, 1)}
+	turns[0] <- struct{}{}
+	for g := range turns {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			tx, err := drv.Tx(ctx)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			for i := 0; i < 3; i++ {
+				<-turns[g]
+				if err := tx.Exec(ctx, "UPDATE users SET age = 1", []any{}, nil); err == nil {
+					t.Error("got no error from a busy statement")
+				}
+				turns[1-g] <- struct{}{}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	stats := drv.LockStats()
+	if stats.Busy != 6 || stats.Warnings != 2 || stats.Consecutive != 3 {
+		t.Fatalf("got %d busy errors, %d warnings and a streak of %d, want 6, 2 and 3", stats.Busy, stats.Warnings, stats.Consecutive)
+	}
+	if warnings != 2 {
+		t.Fatalf("logged %d warnings, want 2", warnings)
+	}
+	if stats.LastQuery != "UPDATE users SET age = 1" {
+		t.Fatalf("got last query %q", stats.LastQuery)
+	}
+
+}
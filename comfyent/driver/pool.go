@@ -0,0 +1,124 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// ErrPoolExhausted is matched by the error returned when no connection could be acquired in time.
+var ErrPoolExhausted = errors.New("connection pool exhausted")
+
+// PoolExhaustedError reports that no connection became available within Timeout, along with
+// the state of the pool at the time.
+type PoolExhaustedError struct {
+	Timeout time.Duration
+	Stats   sql.DBStats
+}
+
+func (e *PoolExhaustedError) Error() string {
+	return fmt.Sprintf("%v: no connection within %s (open %d, in use %d, idle %d, max %d, waited %d times)",
+		ErrPoolExhausted, e.Timeout, e.Stats.OpenConnections, e.Stats.InUse, e.Stats.Idle,
+		e.Stats.MaxOpenConnections, e.Stats.WaitCount)
+}
+
+func (e *PoolExhaustedError) Unwrap() error {
+	return ErrPoolExhausted
+}
+
+// AcquireTimeoutDriver is a dialect.Driver failing with a *PoolExhaustedError when it waits more
+// than its timeout for a connection of db, instead of waiting as long as the context allows.
+// Once acquired, statements and transactions run under their own context.
+type AcquireTimeoutDriver struct {
+	*entsql.Driver
+	db      *sql.DB
+	timeout time.Duration
+}
+
+// NewAcquireTimeoutDriver returns a driver on db waiting at most timeout for a connection, which
+// mostly matters when the pool is capped with SetMaxOpenConns.
+func NewAcquireTimeoutDriver(db *sql.DB, timeout time.Duration) *AcquireTimeoutDriver {
+	return &AcquireTimeoutDriver{
+		Driver:  entsql.OpenDB(dialect.SQLite, db),
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (d *AcquireTimeoutDriver) conn(ctx context.Context) (*sql.Conn, error) {
+	actx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	conn, err := d.db.Conn(actx)
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return nil, &PoolExhaustedError{Timeout: d.timeout, Stats: d.db.Stats()}
+	}
+	return conn, err
+}
+
+// release gives conn back to the pool once the rows or transaction started on it are done:
+// closing a *sql.Conn waits for them.
+func release(conn *sql.Conn) {
+	go conn.Close()
+}
+
+// Exec executes a statement on a connection acquired within the timeout.
+func (d *AcquireTimeoutDriver) Exec(ctx context.Context, query string, args, v any) error {
+	conn, err := d.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return entsql.Conn{ExecQuerier: conn}.Exec(ctx, query, args, v)
+}
+
+// Query runs a query on a connection acquired within the timeout.
+func (d *AcquireTimeoutDriver) Query(ctx context.Context, query string, args, v any) error {
+	conn, err := d.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release(conn)
+	return entsql.Conn{ExecQuerier: conn}.Query(ctx, query, args, v)
+}
+
+// ExecContext executes a statement, for ent's ExecContext.
+func (d *AcquireTimeoutDriver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var res sql.Result
+	if err := d.Exec(ctx, query, args, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// QueryContext runs a query, for ent's QueryContext.
+func (d *AcquireTimeoutDriver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	var rows entsql.Rows
+	if err := d.Query(ctx, query, args, &rows); err != nil {
+		return nil, err
+	}
+	return rows.ColumnScanner.(*sql.Rows), nil
+}
+
+// Tx starts a transaction on a connection acquired within the timeout.
+func (d *AcquireTimeoutDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.BeginTx(ctx, nil)
+}
+
+// BeginTx starts a transaction with the given options on a connection acquired within the timeout.
+func (d *AcquireTimeoutDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	conn, err := d.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release(conn)
+	tx, err := conn.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &entsql.Tx{Conn: entsql.Conn{ExecQuerier: tx}, Tx: tx}, nil
+}
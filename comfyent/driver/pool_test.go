@@ -0,0 +1,61 @@
+package driver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+)
+
+func TestAcquireTimeoutDriver(t *testing.T) {
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	db := comfyent.OpenDB(comfy)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	drv := driver.NewAcquireTimeoutDriver(db, 50*time.Millisecond)
+	ctx := context.Background()
+
+	if _, err := drv.ExecContext(ctx, "SELECT 1;"); err != nil {
+		t.Fatalf("got %v with a free connection, want nil", err)
+	}
+
+	// A transaction holds the only connection.
+	tx, err := drv.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	_, err = drv.ExecContext(ctx, "SELECT 1;")
+	var perr *driver.PoolExhaustedError
+	if !errors.Is(err, driver.ErrPoolExhausted) || !errors.As(err, &perr) {
+		t.Fatalf("got %v with the connection taken, want ErrPoolExhausted", err)
+	}
+	if perr.Stats.MaxOpenConnections != 1 || perr.Stats.InUse != 1 {
+		t.Fatalf("got stats %+v, want the single connection in use", perr.Stats)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("waited %s, want about 50ms", d)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	// The connection is released asynchronously once the transaction ends.
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err = drv.ExecContext(ctx, "SELECT 1;"); err == nil || time.Now().After(deadline) {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("got %v once the transaction ended, want nil", err)
+	}
+}
@@ -0,0 +1,154 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"entgo.io/ent/dialect"
+)
+
+// ErrReadOnly is returned for statements rejected by a read-only driver.
+var ErrReadOnly = errors.New("read-only client")
+
+// NewReadOnlyDriver returns a driver refusing, before they reach SQLite, every statement of
+// inner that isn't a SELECT, a PRAGMA reading a value, an EXPLAIN or a WITH query. WITH queries
+// whose statement writes, e.g. WITH x AS (...) DELETE FROM users, are refused as well, and so
+// are PRAGMAs setting a value, e.g. PRAGMA query_only = OFF, or acting on the database.
+func NewReadOnlyDriver(inner dialect.Driver) *Driver {
+	return Wrap(inner, func(next Handler) Handler {
+		return func(ctx context.Context, stmt Statement, v any) error {
+			if err := checkRead(stmt.Query); err != nil {
+				return err
+			}
+			return next(ctx, stmt, v)
+		}
+	})
+}
+
+// checkRead returns an ErrReadOnly error unless query is read-only, see isRead.
+func checkRead(query string) error {
+	if !isRead(query) {
+		return fmt.Errorf("%w: %s statements are not allowed", ErrReadOnly, Keyword(query))
+	}
+	return nil
+}
+
+// isRead reports whether query is a SELECT, a PRAGMA reading a value, an EXPLAIN or a WITH
+// query that doesn't write.
+func isRead(query string) bool {
+	switch kw, rest := splitKeyword(query); kw {
+	case "select", "explain":
+		return true
+	case "pragma":
+		return isReadPragma(rest)
+	case "with":
+		return !hasWriteKeyword(query)
+	}
+	return false
+}
+
+// readPragmaArgs are the pragmas whose argument, in parentheses, names what to read rather than
+// a value to set.
+var readPragmaArgs = map[string]bool{
+	"foreign_key_check": true,
+	"foreign_key_list":  true,
+	"index_info":        true,
+	"index_list":        true,
+	"index_xinfo":       true,
+	"integrity_check":   true,
+	"quick_check":       true,
+	"table_info":        true,
+	"table_xinfo":       true,
+}
+
+// writePragmas are the pragmas acting on the database even without a value.
+var writePragmas = map[string]bool{
+	"incremental_vacuum": true,
+	"optimize":           true,
+	"shrink_memory":      true,
+	"wal_checkpoint":     true,
+}
+
+// isReadPragma reports whether pragma, a PRAGMA statement without its keyword, reads a value:
+// it has no value, or an argument naming what to read.
+func isReadPragma(pragma string) bool {
+	pragma = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(pragma), ";"))
+	end := strings.IndexFunc(pragma, func(r rune) bool {
+		return r != '.' && r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if end < 0 {
+		end = len(pragma)
+	}
+	name := strings.ToLower(pragma[:end])
+	// Drop the schema, as in main.table_info.
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	switch arg := strings.TrimSpace(pragma[end:]); {
+	case arg == "":
+		return !writePragmas[name]
+	case strings.HasPrefix(arg, "("):
+		return readPragmaArgs[name]
+	}
+	return false
+}
+
+// writeKeywords are the keywords of the statements a common table expression can lead to
+// besides SELECT.
+var writeKeywords = map[string]bool{
+	"insert":  true,
+	"update":  true,
+	"delete":  true,
+	"replace": true,
+}
+
+// hasWriteKeyword reports whether query holds one of writeKeywords outside of string literals,
+// quoted identifiers and comments.
+func hasWriteKeyword(query string) bool {
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			end := c
+			if c == '[' {
+				end = ']'
+			}
+			j := strings.IndexByte(query[i+1:], end)
+			if j < 0 {
+				return false
+			}
+			i += j + 2
+		case strings.HasPrefix(query[i:], "--"):
+			j := strings.IndexByte(query[i:], '\n')
+			if j < 0 {
+				return false
+			}
+			i += j + 1
+		case strings.HasPrefix(query[i:], "/*"):
+			j := strings.Index(query[i:], "*/")
+			if j < 0 {
+				return false
+			}
+			i += j + 2
+		case isWordByte(c):
+			j := i
+			for j < len(query) && isWordByte(query[j]) {
+				j++
+			}
+			if writeKeywords[strings.ToLower(query[i:j])] {
+				return true
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+	return false
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
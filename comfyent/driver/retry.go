@@ -0,0 +1,70 @@
+package driver
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// RetryPolicy tells NewRetryDriver how to retry failed statements.
+type RetryPolicy struct {
+	// Attempts is the total number of times a statement is run. Values below 1 are treated as 1.
+	Attempts int
+	// Backoff is the time waited after the first failed attempt, doubled after each of the next
+	// ones up to MaxBackoff.
+	Backoff time.Duration
+	// MaxBackoff caps the time waited between two attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Jitter randomizes each wait by up to this fraction of it, either way, so clients failing
+	// together don't retry in lockstep. It is clamped to [0, 1].
+	Jitter float64
+}
+
+// wait returns the time to wait after the given failed attempt, starting at 1.
+func (p RetryPolicy) wait(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 {
+		d = min(d, p.MaxBackoff)
+	}
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * jitter * float64(d))
+	}
+	return d
+}
+
+// NewRetryDriver returns a driver retrying the statements of inner failing with a transient
+// error, SQLITE_BUSY, SQLITE_LOCKED or SQLITE_IOERR, as told by policy. Other errors are
+// returned right away.
+//
+// Like NewIOErrorRetryDriver, it leaves statements within transactions alone: those are
+// retried as a whole, see tx.WithTxRetry.
+func NewRetryDriver(inner dialect.Driver, policy RetryPolicy) *Driver {
+	return newRetryDriver(inner, policy, func(err error) bool {
+		return isBusy(err) || isIOError(err)
+	})
+}
+
+// newRetryDriver returns a driver retrying the statements of inner outside of transactions
+// failing with an error for which retryable holds.
+func newRetryDriver(inner dialect.Driver, policy RetryPolicy, retryable func(error) bool) *Driver {
+	attempts := max(policy.Attempts, 1)
+	return Wrap(inner, func(next Handler) Handler {
+		return func(ctx context.Context, stmt Statement, v any) error {
+			err := next(ctx, stmt, v)
+			for attempt := 1; attempt < attempts && !stmt.InTx && err != nil && retryable(err); attempt++ {
+				select {
+				case <-ctx.Done():
+					return err
+				case <-time.After(policy.wait(attempt)):
+				}
+				err = next(ctx, stmt, v)
+			}
+			return err
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package driver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestRetryDriver(t *testing.T) {
+	ctx := context.Background()
+	policy := driver.RetryPolicy{Attempts: 4, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, Jitter: 0.5}
+
+	for _, code := range []sqlite3.ErrNo{sqlite3.ErrBusy, sqlite3.ErrLocked, sqlite3.ErrIoErr} {
+		inner := &fakeDriver{fail: failFirst(3, sqlite3.Error{Code: code})}
+		drv := driver.NewRetryDriver(inner, policy)
+		if err := drv.Query(ctx, "SELECT 1", []any{}, nil); err != nil {
+			t.Fatalf("got %v failing 3 times with %v, want the last attempt to succeed", err, code)
+		}
+		if n := len(inner.Queries()); n != 4 {
+			t.Fatalf("ran the statement %d times failing with %v, want 4", n, code)
+		}
+	}
+
+	// Attempts bounds the retries.
+	inner := &fakeDriver{fail: failFirst(10, sqlite3.Error{Code: sqlite3.ErrBusy})}
+	drv := driver.NewRetryDriver(inner, policy)
+	var serr sqlite3.Error
+	if err := drv.Exec(ctx, "UPDATE users SET age = 1", []any{}, nil); !errors.As(err, &serr) || serr.Code != sqlite3.ErrBusy {
+		t.Fatalf("got %v, want the busy error once out of attempts", err)
+	}
+	if n := len(inner.Queries()); n != 4 {
+		t.Fatalf("ran the statement %d times, want 4", n)
+	}
+
+	// Other errors aren't retried.
+	errBoom := errors.New("boom")
+	inner = &fakeDriver{fail: failFirst(1, errBoom)}
+	drv = driver.NewRetryDriver(inner, policy)
+	if err := drv.Exec(ctx, "UPDATE users SET age = 1", []any{}, nil); !errors.Is(err, errBoom) {
+		t.Fatalf("got %v, want %v", err, errBoom)
+	}
+	if n := len(inner.Queries()); n != 1 {
+		t.Fatalf("ran the statement %d times, want 1", n)
+	}
+}
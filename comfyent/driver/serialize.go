@@ -0,0 +1,218 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// ErrWriteLockTimeout is returned for the writes of a SerializedWriter that waited too long for
+// the writes in progress, typically for a transaction left open.
+var ErrWriteLockTimeout = errors.New("driver: timed out waiting for the write lock")
+
+// defaultWriteLockWait is how long writes wait for the lock when NewSerializedWriter is given no
+// wait, comfylite3's busy timeout.
+const defaultWriteLockWait = 5 * time.Second
+
+// writeLock lets a single writer in at a time.
+type writeLock struct {
+	sem  chan struct{}
+	refs int
+}
+
+var (
+	writeLocksMu sync.Mutex
+	// writeLocks are the locks of the databases with SerializedWriters open, by name.
+	writeLocks = make(map[string]*writeLock)
+)
+
+// acquire takes the lock, waiting at most wait for it, or until ctx is done.
+func (l *writeLock) acquire(ctx context.Context, wait time.Duration) error {
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	default:
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return ErrWriteLockTimeout
+	}
+}
+
+func (l *writeLock) release() {
+	<-l.sem
+}
+
+// SerializedWriter is a driver letting a single writer in at a time per database, see
+// NewSerializedWriter.
+type SerializedWriter struct {
+	*Driver
+	database string
+	lock     *writeLock
+	wait     time.Duration
+	once     sync.Once
+}
+
+// NewSerializedWriter returns a driver running the writes of inner one at a time, along with
+// those of every other SerializedWriter of the process on the same database, whatever names it
+// uniquely, such as its absolute path: statements other than a SELECT, a PRAGMA, an EXPLAIN or
+// a WITH query wait for the previous write to finish, and transactions hold the lock from
+// their start to their commit or rollback. Writers thus never contend for the database, and
+// don't fail with SQLITE_BUSY under cache=shared, at the cost of write throughput. Reads
+// outside of transactions still run concurrently.
+//
+// Writes wait at most wait for the lock, 5s if zero, then fail with ErrWriteLockTimeout, as a
+// goroutine writing outside of the transaction it holds would otherwise wait forever.
+// Read-only transactions, started with BeginTx, don't hold the lock.
+func NewSerializedWriter(inner dialect.Driver, database string, wait time.Duration) *SerializedWriter {
+	if wait <= 0 {
+		wait = defaultWriteLockWait
+	}
+	writeLocksMu.Lock()
+	lock, ok := writeLocks[database]
+	if !ok {
+		lock = &writeLock{sem: make(chan struct{}, 1)}
+		writeLocks[database] = lock
+	}
+	lock.refs++
+	writeLocksMu.Unlock()
+	return &SerializedWriter{
+		Driver: Wrap(inner, func(next Handler) Handler {
+			return func(ctx context.Context, stmt Statement, v any) error {
+				if stmt.InTx || isRead(stmt.Query) {
+					return next(ctx, stmt, v)
+				}
+				if err := lock.acquire(ctx, wait); err != nil {
+					return err
+				}
+				defer lock.release()
+				return next(ctx, stmt, v)
+			}
+		}),
+		database: database,
+		lock:     lock,
+		wait:     wait,
+	}
+}
+
+// Tx waits for the writes in progress, then starts a transaction holding off the others.
+func (s *SerializedWriter) Tx(ctx context.Context) (dialect.Tx, error) {
+	if err := s.lock.acquire(ctx, s.wait); err != nil {
+		return nil, err
+	}
+	tx, err := s.Driver.Tx(ctx)
+	if err != nil {
+		s.lock.release()
+		return nil, err
+	}
+	return &serializedTx{Tx: tx, lock: s.lock}, nil
+}
+
+// BeginTx is like Tx, with the given options. Read-only transactions start right away and don't
+// hold off writes: theirs are refused with ErrReadOnly, as SQLite doesn't enforce the option.
+func (s *SerializedWriter) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	if opts != nil && opts.ReadOnly {
+		tx, err := s.Driver.BeginTx(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &readOnlyTx{Tx: tx}, nil
+	}
+	if err := s.lock.acquire(ctx, s.wait); err != nil {
+		return nil, err
+	}
+	tx, err := s.Driver.BeginTx(ctx, opts)
+	if err != nil {
+		s.lock.release()
+		return nil, err
+	}
+	return &serializedTx{Tx: tx, lock: s.lock}, nil
+}
+
+// Close closes inner, and drops the lock of the database once its last writer is closed.
+func (s *SerializedWriter) Close() error {
+	s.once.Do(func() {
+		writeLocksMu.Lock()
+		defer writeLocksMu.Unlock()
+		if s.lock.refs--; s.lock.refs == 0 {
+			delete(writeLocks, s.database)
+		}
+	})
+	return s.Driver.Close()
+}
+
+// serializedTx is a transaction releasing the write lock once it ends.
+type serializedTx struct {
+	dialect.Tx
+	lock *writeLock
+	once sync.Once
+}
+
+func (t *serializedTx) Commit() error {
+	defer t.release()
+	return t.Tx.Commit()
+}
+
+func (t *serializedTx) Rollback() error {
+	defer t.release()
+	return t.Tx.Rollback()
+}
+
+func (t *serializedTx) release() {
+	t.once.Do(t.lock.release)
+}
+
+// ExecContext runs an Exec statement within the transaction, for ent's ExecContext.
+func (t *serializedTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.Tx.(*Tx).ExecContext(ctx, query, args...)
+}
+
+// QueryContext runs a Query statement within the transaction, for ent's QueryContext.
+func (t *serializedTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.Tx.(*Tx).QueryContext(ctx, query, args...)
+}
+
+// readOnlyTx is a read-only transaction refusing writes.
+type readOnlyTx struct {
+	dialect.Tx
+}
+
+func (t *readOnlyTx) Exec(ctx context.Context, query string, args, v any) error {
+	if err := checkRead(query); err != nil {
+		return err
+	}
+	return t.Tx.Exec(ctx, query, args, v)
+}
+
+func (t *readOnlyTx) Query(ctx context.Context, query string, args, v any) error {
+	if err := checkRead(query); err != nil {
+		return err
+	}
+	return t.Tx.Query(ctx, query, args, v)
+}
+
+// ExecContext runs an Exec statement within the transaction, for ent's ExecContext.
+func (t *readOnlyTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if err := checkRead(query); err != nil {
+		return nil, err
+	}
+	return t.Tx.(*Tx).ExecContext(ctx, query, args...)
+}
+
+// QueryContext runs a Query statement within the transaction, for ent's QueryContext.
+func (t *readOnlyTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if err := checkRead(query); err != nil {
+		return nil, err
+	}
+	return t.Tx.(*Tx).QueryContext(ctx, query, args...)
+}
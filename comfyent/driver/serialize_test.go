@@ -0,0 +1,106 @@
+package driver_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	_ "github.com/davidroman0O/comfylite3-ent/ent/runtime"
+)
+
+// openSerialized opens a client serializing its writes on the database at path.
+func openSerialized(t *testing.T, path string, busyTimeout time.Duration) *ent.Client {
+	t.Helper()
+	client, comfy, err := comfyent.Open(comfyent.Config{
+		Path:            path,
+		SharedCache:     true,
+		ForeignKeys:     true,
+		BusyTimeout:     busyTimeout,
+		SerializeWrites: true,
+	})
+	if err != nil {
+		t.Fatalf("opening %s: %v", path, err)
+	}
+	t.Cleanup(func() {
+		client.Close()
+		comfy.Close()
+	})
+	return client
+}
+
+func TestSerializedWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	clients := []*ent.Client{openSerialized(t, path, 0), openSerialized(t, path, 0)}
+	ctx := context.Background()
+	if err := clients[0].Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	const goroutines, writes = 20, 25
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			client := clients[g%len(clients)]
+			for i := 0; i < writes; i++ {
+				err := client.User.Create().SetName("u").SetAge(30).SetEmail(fmt.Sprintf("u%d-%d@example.com", g, i)).Exec(ctx)
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if strings.Contains(err.Error(), "locked") || strings.Contains(err.Error(), "busy") {
+			t.Errorf("got busy error %v", err)
+		}
+	}
+	if len(errs) > 0 {
+		t.Fatalf("got %d failed writes, first: %v", len(errs), errs[0])
+	}
+	if n := clients[0].User.Query().CountX(ctx); n != goroutines*writes {
+		t.Fatalf("got %d users, want %d", n, goroutines*writes)
+	}
+}
+
+func TestSerializedWriterTimeout(t *testing.T) {
+	client := openSerialized(t, filepath.Join(t.TempDir(), "test.db"), 100*time.Millisecond)
+	other := openSerialized(t, filepath.Join(t.TempDir(), "other.db"), 100*time.Millisecond)
+	ctx := context.Background()
+	for _, c := range []*ent.Client{client, other} {
+		if err := c.Schema.Create(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	// Writing outside of the transaction this goroutine holds fails instead of waiting forever.
+	err = client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").Exec(ctx)
+	if !errors.Is(err, driver.ErrWriteLockTimeout) {
+		t.Fatalf("got error %v writing outside of the open transaction, want ErrWriteLockTimeout", err)
+	}
+	// Other databases don't wait for the transaction.
+	if err := other.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").Exec(ctx); err != nil {
+		t.Fatalf("writing to another database: %v", err)
+	}
+}
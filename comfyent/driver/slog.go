@@ -0,0 +1,43 @@
+package driver
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// NewSlogDriver returns a driver logging every statement of inner, with its duration, to logger
+// at debug level, so it stays silent unless the handler enables debug logs.
+//
+// Bind arguments are replaced by their count unless withArgs is set, as they may hold values
+// such as emails that don't belong in logs.
+func NewSlogDriver(inner dialect.Driver, logger *slog.Logger, withArgs bool) *Driver {
+	return Wrap(inner, func(next Handler) Handler {
+		return func(ctx context.Context, stmt Statement, v any) error {
+			if !logger.Enabled(ctx, slog.LevelDebug) {
+				return next(ctx, stmt, v)
+			}
+			start := time.Now()
+			err := next(ctx, stmt, v)
+			attrs := []slog.Attr{
+				slog.String("op", string(stmt.Op)),
+				slog.String("query", stmt.Query),
+				slog.Duration("duration", time.Since(start)),
+				slog.Bool("tx", stmt.InTx),
+			}
+			args, _ := stmt.Args.([]any)
+			if withArgs {
+				attrs = append(attrs, slog.Any("args", args))
+			} else {
+				attrs = append(attrs, slog.Int("args", len(args)))
+			}
+			if err != nil {
+				attrs = append(attrs, slog.Any("error", err))
+			}
+			logger.LogAttrs(ctx, slog.LevelDebug, "sql", attrs...)
+			return err
+		}
+	})
+}
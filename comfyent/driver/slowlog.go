@@ -0,0 +1,27 @@
+package driver
+
+import (
+	"context"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// NewSlowQueryLogger returns a driver calling logf for every Exec or Query of inner
+// taking longer than threshold.
+//
+// logf receives the statement with its "?" placeholders; bind arguments are never
+// passed along, so values such as emails don't end up in logs. Statements finishing
+// under threshold cost a clock read and nothing else.
+func NewSlowQueryLogger(inner dialect.Driver, threshold time.Duration, logf func(sql string, d time.Duration)) *Driver {
+	return Wrap(inner, func(next Handler) Handler {
+		return func(ctx context.Context, stmt Statement, v any) error {
+			start := time.Now()
+			err := next(ctx, stmt, v)
+			if d := time.Since(start); d > threshold {
+				logf(stmt.Query, d)
+			}
+			return err
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package driver_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+)
+
+func TestSlowQueryLogger(t *testing.T) {
+	inner := &fakeDriver{}
+	var (
+		mu     sync.Mutex
+		logged []string
+	)
+	drv := driver.NewSlowQueryLogger(inner, 50*time.Millisecond, func(sql string, d time.Duration) {
+		mu.Lock()
+		logged = append(logged, sql)
+		mu.Unlock()
+	})
+	ctx := context.Background()
+
+	if err := drv.Query(ctx, "SELECT fast", []any{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	inner.delay = 100 * time.Millisecond
+	if err := drv.Exec(ctx, "UPDATE slow", []any{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(logged) != 1 || logged[0] != "UPDATE slow" {
+		t.Fatalf("logged %q, want only the slow statement", logged)
+	}
+}
@@ -0,0 +1,38 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// NewTimeoutDriver returns a driver cancelling any statement of inner still running after d.
+// Statements whose context already carries a deadline keep it, so a caller can still
+// pick a shorter or longer one, e.g. with comfyent.WithQueryTimeout.
+//
+// A statement cancelled this way fails with an error matching context.DeadlineExceeded.
+func NewTimeoutDriver(inner dialect.Driver, d time.Duration) *Driver {
+	return Wrap(inner, func(next Handler) Handler {
+		return func(ctx context.Context, stmt Statement, v any) error {
+			if _, ok := ctx.Deadline(); ok {
+				return next(ctx, stmt, v)
+			}
+			ctx, cancel := context.WithTimeout(ctx, d)
+			err := next(ctx, stmt, v)
+			if errors.Is(err, context.DeadlineExceeded) {
+				err = fmt.Errorf("statement timed out after %s: %w", d, err)
+			}
+			if stmt.Op == OpQuery && err == nil {
+				// Rows are read after the statement returns, so the deadline must outlive it:
+				// the context is released once it expires instead.
+				time.AfterFunc(d, cancel)
+				return nil
+			}
+			cancel()
+			return err
+		}
+	})
+}
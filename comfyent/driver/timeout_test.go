@@ -0,0 +1,32 @@
+package driver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+)
+
+func TestTimeoutDriver(t *testing.T) {
+	inner := &fakeDriver{delay: time.Second}
+	drv := driver.NewTimeoutDriver(inner, 20*time.Millisecond)
+
+	start := time.Now()
+	err := drv.Exec(context.Background(), "UPDATE slow", []any{}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("statement ran for %s, want it cancelled after 20ms", d)
+	}
+
+	// A deadline of the caller wins over the default one.
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	inner.delay = 50 * time.Millisecond
+	if err := drv.Exec(ctx, "UPDATE slow", []any{}, nil); err != nil {
+		t.Fatalf("got %v, want the caller's deadline to apply", err)
+	}
+}
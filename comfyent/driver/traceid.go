@@ -0,0 +1,46 @@
+package driver
+
+import (
+	"context"
+	"strings"
+
+	"entgo.io/ent/dialect"
+)
+
+type traceIDKey struct{}
+
+// WithTraceID returns a context whose statements are tagged with id by a trace comment driver.
+func WithTraceID(parent context.Context, id string) context.Context {
+	return context.WithValue(parent, traceIDKey{}, id)
+}
+
+// TraceIDFromContext returns the trace ID set by WithTraceID, if any.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(traceIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// NewTraceCommentDriver returns a driver appending a /* trace:<id> */ comment to the statements
+// of inner run with a context carrying a trace ID, so slow query logs can be matched with the
+// request that issued them. Arguments are bound as before: only the SQL text changes.
+func NewTraceCommentDriver(inner dialect.Driver) *Driver {
+	return Wrap(inner, func(next Handler) Handler {
+		return func(ctx context.Context, stmt Statement, v any) error {
+			if id, ok := TraceIDFromContext(ctx); ok {
+				stmt.Query += " /* trace:" + sanitizeTraceID(id) + " */"
+			}
+			return next(ctx, stmt, v)
+		}
+	})
+}
+
+// sanitizeTraceID drops the characters that could end the comment early or confuse log parsers.
+func sanitizeTraceID(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', strings.ContainsRune("-_.:", r):
+			return r
+		}
+		return -1
+	}, id)
+}
@@ -0,0 +1,39 @@
+package driver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+)
+
+func TestTraceCommentDriver(t *testing.T) {
+	inner := &fakeDriver{}
+	drv := driver.NewTraceCommentDriver(inner)
+	ctx := context.Background()
+
+	if err := drv.Query(ctx, "SELECT 1", []any{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := drv.Exec(driver.WithTraceID(ctx, "req-42"), "UPDATE users SET age = ?", []any{1}, nil); err != nil {
+		t.Fatal(err)
+	}
+	// Characters able to end the comment are dropped.
+	if err := drv.Query(driver.WithTraceID(ctx, "evil */ DROP"), "SELECT 2", []any{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"SELECT 1",
+		"UPDATE users SET age = ? /* trace:req-42 */",
+		"SELECT 2 /* trace:evilDROP */",
+	}
+	got := inner.Queries()
+	if len(got) != len(want) {
+		t.Fatalf("got queries %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got query %q, want %q", got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	atlas "ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	entschema "github.com/davidroman0O/comfylite3-ent/ent/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// emailIndexDDL makes SQLite itself reject emails differing only by case.
+const emailIndexDDL = "CREATE UNIQUE INDEX IF NOT EXISTS users_email_lower_key ON users (lower(email))"
+
+// WithCaseInsensitiveEmail is a migration option adding a unique index on lower(email):
+//
+//	client.Schema.Create(ctx, comfyent.WithCaseInsensitiveEmail())
+//
+// The User schema lowercases emails before saving them, but statements run outside of ent
+// skip that hook. ent can't declare expression indexes, hence the extra migration step.
+// It fails if the table already holds emails differing only by case. On encrypted emails, see
+// Config.EncryptionKey, lower() sees the ciphertexts, so the index only rejects exact
+// duplicates: the schema hook lowercasing emails is all that's left.
+func WithCaseInsensitiveEmail() schema.MigrateOption {
+	return schema.WithApplyHook(func(next schema.Applier) schema.Applier {
+		return schema.ApplyFunc(func(ctx context.Context, conn dialect.ExecQuerier, plan *atlas.Plan) error {
+			if err := next.Apply(ctx, conn, plan); err != nil {
+				return err
+			}
+			if err := conn.Exec(ctx, emailIndexDDL, []any{}, nil); err != nil {
+				return fmt.Errorf("creating case-insensitive email index: %w", err)
+			}
+			return nil
+		})
+	})
+}
+
+// ChangeEmail sets the email of the user with the given ID, failing with ErrEmailTaken when
+// another user, soft-deleted ones included, already has it. The check and the update run in
+// one transaction, and a racing writer claiming the email in between also yields ErrEmailTaken.
+func ChangeEmail(ctx context.Context, client *ent.Client, id int, newEmail string) (err error) {
+	email := entschema.NormalizeEmail(newEmail)
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("changing email of user %d: %w", id, err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			if field, ok := IsUniqueViolation(err); ok && field == user.FieldEmail {
+				err = ErrEmailTaken
+			}
+			err = fmt.Errorf("changing email of user %d: %w", id, err)
+		}
+	}()
+	// The unique index covers soft-deleted users too.
+	taken, err := tx.User.Query().
+		Where(user.Email(email), user.IDNEQ(id)).
+		Exist(entschema.SkipSoftDelete(ctx))
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrEmailTaken
+	}
+	if err := tx.User.UpdateOneID(id).SetEmail(email).Exec(ctx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
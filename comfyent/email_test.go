@@ -0,0 +1,61 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestWithCaseInsensitiveEmail(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx, comfyent.WithCaseInsensitiveEmail()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Raw statements skip the hook lowercasing emails, the index alone rejects them.
+	const insert = "INSERT INTO users (created_at, updated_at, name, age, email) VALUES (datetime('now'), datetime('now'), 'a', 30, ?)"
+	if _, err := client.ExecContext(ctx, insert, "A@x.com"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := client.ExecContext(ctx, insert, "a@x.com")
+	if field, ok := comfyent.IsUniqueViolation(err); !ok || field != "email" {
+		t.Fatalf("got %v inserting a@x.com next to A@x.com, want a unique violation on the email", err)
+	}
+}
+
+func TestChangeEmail(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	a := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	b := client.User.Create().SetName("b").SetAge(30).SetEmail("b@example.com").SaveX(ctx)
+	deleted := client.User.Create().SetName("c").SetAge(30).SetEmail("c@example.com").SaveX(ctx)
+	client.User.DeleteOne(deleted).ExecX(ctx)
+
+	if err := comfyent.ChangeEmail(ctx, client, a.ID, "B@example.com"); !errors.Is(err, comfyent.ErrEmailTaken) {
+		t.Fatalf("got %v taking b's email, want ErrEmailTaken", err)
+	}
+	// Soft-deleted users keep their email.
+	if err := comfyent.ChangeEmail(ctx, client, a.ID, "c@example.com"); !errors.Is(err, comfyent.ErrEmailTaken) {
+		t.Fatalf("got %v taking a deleted user's email, want ErrEmailTaken", err)
+	}
+	if got := client.User.GetX(ctx, a.ID); got.Email != "a@example.com" {
+		t.Fatalf("got email %s after failed changes, want a@example.com", got.Email)
+	}
+
+	if err := comfyent.ChangeEmail(ctx, client, a.ID, "New@Example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if got := client.User.GetX(ctx, a.ID); got.Email != "new@example.com" {
+		t.Fatalf("got email %s, want new@example.com", got.Email)
+	}
+	// Keeping one's own email isn't a conflict.
+	if err := comfyent.ChangeEmail(ctx, client, b.ID, "b@example.com"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,219 @@
+package comfyent
+
+import (
+	"database/sql/driver"
+	"strings"
+)
+
+// encryptArgs encrypts the arguments of query bound to the columns of encryptedColumns, if the
+// conn has a cipher. EncryptedString values reach the driver as plain strings, so the key and
+// what it encrypts stay with the conns of the client opened with it.
+func (c *conn) encryptArgs(query string, args []driver.NamedValue) {
+	if c.cipher == nil {
+		return
+	}
+	for _, i := range encryptedArgs(query) {
+		if i >= len(args) || args[i].Name != "" {
+			continue
+		}
+		switch v := args[i].Value.(type) {
+		case string:
+			args[i].Value = c.cipher.Encrypt(v)
+		case []byte:
+			args[i].Value = c.cipher.Encrypt(string(v))
+		}
+	}
+}
+
+// encryptedArgs returns the positions of the placeholders of query bound to the columns of
+// encryptedColumns, in the statements ent builds:
+//
+//	INSERT INTO `users` (`name`, `email`) VALUES (?, ?), (?, ?)
+//	UPDATE `users` SET `email` = ? WHERE `users`.`email` IN (?, ?)
+//
+// Placeholders compared otherwise, e.g. within LIKE or lower(), are left alone: those
+// predicates don't match encrypted values.
+func encryptedArgs(query string) []int {
+	toks := sqlTokens(query)
+	// args numbers the placeholders, -1 for the other tokens.
+	args := make([]int, len(toks))
+	n := 0
+	for i, t := range toks {
+		args[i] = -1
+		if t.is("?") {
+			args[i] = n
+			n++
+		}
+	}
+	var pos []int
+	for i := 0; i < len(toks); i++ {
+		switch {
+		case toks[i].keyword("INSERT"):
+			pos = append(pos, insertedArgs(toks[i+1:], args[i+1:])...)
+		case encryptedColumn(toks, i):
+			j := i + 1
+			if j < len(toks) && toks[j].comparison() {
+				if j+1 < len(toks) && args[j+1] >= 0 {
+					pos = append(pos, args[j+1])
+				}
+				continue
+			}
+			if j < len(toks) && toks[j].keyword("NOT") {
+				j++
+			}
+			if j+2 >= len(toks) || !toks[j].keyword("IN") || !toks[j+1].is("(") || toks[j+2].keyword("SELECT") {
+				continue
+			}
+			for k := j + 2; k < len(toks) && !toks[k].is(")"); k++ {
+				if args[k] >= 0 {
+					pos = append(pos, args[k])
+				}
+			}
+		}
+	}
+	return pos
+}
+
+// insertedArgs returns the positions of the placeholders bound to the columns of
+// encryptedColumns in the VALUES of an INSERT, given the tokens following INSERT.
+func insertedArgs(toks []sqlToken, args []int) []int {
+	// The column list is the first parenthesis, unless the values come first.
+	i := 0
+	for ; i < len(toks) && !toks[i].is("("); i++ {
+		if toks[i].keyword("VALUES") || toks[i].keyword("SELECT") || toks[i].keyword("DEFAULT") {
+			return nil
+		}
+	}
+	var columns []bool
+	for i++; i < len(toks) && !toks[i].is(")"); i++ {
+		if !toks[i].is(",") {
+			columns = append(columns, encryptedColumns[strings.ToLower(toks[i].text)])
+		}
+	}
+	if i+1 >= len(toks) || !toks[i+1].keyword("VALUES") {
+		return nil
+	}
+	var pos []int
+	depth, column := 0, 0
+	for i += 2; i < len(toks); i++ {
+		switch {
+		case toks[i].is("("):
+			if depth++; depth == 1 {
+				column = 0
+			}
+		case toks[i].is(")"):
+			depth--
+		case toks[i].is(","):
+			if depth == 1 {
+				column++
+			}
+		case depth == 0:
+			// ON CONFLICT or RETURNING.
+			return pos
+		case args[i] >= 0 && column < len(columns) && columns[column]:
+			pos = append(pos, args[i])
+		}
+	}
+	return pos
+}
+
+// encryptedColumn reports whether toks[i] names a column of encryptedColumns, possibly
+// qualified by its table.
+func encryptedColumn(toks []sqlToken, i int) bool {
+	t := toks[i]
+	if !t.ident || !encryptedColumns[strings.ToLower(t.text)] {
+		return false
+	}
+	return i+1 == len(toks) || !toks[i+1].is(".")
+}
+
+// sqlToken is a token of an SQL statement.
+type sqlToken struct {
+	text string
+	// ident is set for identifiers and keywords, quoted marks the quoted identifiers.
+	ident, quoted bool
+}
+
+func (t sqlToken) is(s string) bool {
+	return !t.ident && t.text == s
+}
+
+func (t sqlToken) keyword(k string) bool {
+	return t.ident && !t.quoted && strings.EqualFold(t.text, k)
+}
+
+func (t sqlToken) comparison() bool {
+	switch {
+	case t.ident:
+		return false
+	case t.text == "=", t.text == "==", t.text == "<>", t.text == "!=",
+		t.text == "<", t.text == "<=", t.text == ">", t.text == ">=":
+		return true
+	}
+	return false
+}
+
+// sqlTokens splits query into tokens. String literals become a single "'" token, comments are
+// dropped.
+func sqlTokens(query string) []sqlToken {
+	var toks []sqlToken
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '`' || c == '"' || c == '[':
+			end := c
+			if c == '[' {
+				end = ']'
+			}
+			j := strings.IndexByte(query[i+1:], end)
+			if j < 0 {
+				j = len(query) - i - 1
+			}
+			toks = append(toks, sqlToken{text: query[i+1 : i+1+j], ident: true, quoted: true})
+			i += j + 2
+		case c == '\'':
+			j := i + 1
+			for ; j < len(query); j++ {
+				if query[j] == '\'' {
+					if j+1 < len(query) && query[j+1] == '\'' {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			toks = append(toks, sqlToken{text: "'"})
+			i = j + 1
+		case strings.HasPrefix(query[i:], "--"):
+			j := strings.IndexByte(query[i:], '\n')
+			if j < 0 {
+				return toks
+			}
+			i += j + 1
+		case isWordByte(c):
+			j := i
+			for j < len(query) && isWordByte(query[j]) {
+				j++
+			}
+			toks = append(toks, sqlToken{text: query[i:j], ident: true})
+			i = j
+		default:
+			n := 1
+			if i+1 < len(query) {
+				switch query[i : i+2] {
+				case "<>", "!=", "<=", ">=", "==":
+					n = 2
+				}
+			}
+			toks = append(toks, sqlToken{text: query[i : i+n]})
+			i += n
+		}
+	}
+	return toks
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
@@ -0,0 +1,84 @@
+package comfyent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/migrate"
+)
+
+// ErrSchemaMissing is returned by EnsureSchema when tables are missing.
+var ErrSchemaMissing = errors.New("database schema is missing")
+
+type ensureOptions struct {
+	create  bool
+	migrate []schema.MigrateOption
+}
+
+// EnsureOption configures EnsureSchema.
+type EnsureOption func(*ensureOptions)
+
+// WithAutoCreate makes EnsureSchema create the missing tables with client.Schema.Create, given
+// opts, instead of failing.
+func WithAutoCreate(opts ...schema.MigrateOption) EnsureOption {
+	return func(o *ensureOptions) {
+		o.create = true
+		o.migrate = opts
+	}
+}
+
+// EnsureSchema checks at startup that every ent table exists, failing with ErrSchemaMissing and
+// the tables missing rather than letting the first query fail with "no such table".
+// Columns aren't compared: a table is enough to tell the schema was created.
+func EnsureSchema(ctx context.Context, client *ent.Client, opts ...EnsureOption) error {
+	var o ensureOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	missing, err := missingTables(ctx, client)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if !o.create {
+		return fmt.Errorf("%w: tables %s not found, run client.Schema.Create(ctx) at startup or use WithAutoCreate",
+			ErrSchemaMissing, strings.Join(missing, ", "))
+	}
+	if err := client.Schema.Create(ctx, o.migrate...); err != nil {
+		return fmt.Errorf("creating schema: %w", err)
+	}
+	return nil
+}
+
+// missingTables returns the ent tables absent from sqlite_master.
+func missingTables(ctx context.Context, client *ent.Client) ([]string, error) {
+	rows, err := client.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table';")
+	if err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+	defer rows.Close()
+	found := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("listing tables: %w", err)
+		}
+		found[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+	var missing []string
+	for _, t := range migrate.Tables {
+		if !found[t.Name] {
+			missing = append(missing, t.Name)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,31 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestEnsureSchema(t *testing.T) {
+	client, comfy, err := comfyent.Open(comfyent.Config{InMemory: true, ForeignKeys: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	defer client.Close()
+	ctx := context.Background()
+
+	err = comfyent.EnsureSchema(ctx, client)
+	if !errors.Is(err, comfyent.ErrSchemaMissing) || !strings.Contains(err.Error(), "users") {
+		t.Fatalf("got %v on a fresh database, want ErrSchemaMissing naming users", err)
+	}
+	if err := comfyent.EnsureSchema(ctx, client, comfyent.WithAutoCreate()); err != nil {
+		t.Fatalf("creating the schema: %v", err)
+	}
+	if err := comfyent.EnsureSchema(ctx, client); err != nil {
+		t.Fatalf("got %v once created, want nil", err)
+	}
+}
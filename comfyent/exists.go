@@ -0,0 +1,18 @@
+package comfyent
+
+import (
+	"context"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/predicate"
+)
+
+// UserExists reports whether at least one user matches all the given predicates.
+func UserExists(ctx context.Context, client *ent.Client, preds ...predicate.User) (bool, error) {
+	return client.User.Query().Where(preds...).Exist(ctx)
+}
+
+// CountUsers returns the number of users matching all the given predicates.
+func CountUsers(ctx context.Context, client *ent.Client, preds ...predicate.User) (int, error) {
+	return client.User.Query().Where(preds...).Count(ctx)
+}
@@ -0,0 +1,40 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestUserExists(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	client.User.Create().SetName("alice").SetAge(30).SetEmail("alice@example.com").ExecX(ctx)
+
+	if ok, err := comfyent.UserExists(ctx, client, user.Email("alice@example.com")); err != nil || !ok {
+		t.Fatalf("got %v and error %v for an existing email, want true", ok, err)
+	}
+	if ok, err := comfyent.UserExists(ctx, client, user.Email("bob@example.com")); err != nil || ok {
+		t.Fatalf("got %v and error %v for a missing email, want false", ok, err)
+	}
+}
+
+func TestCountUsers(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	for _, name := range []string{"alice", "alicia", "bob"} {
+		client.User.Create().SetName(name).SetAge(30).SetEmail(name + "@example.com").ExecX(ctx)
+	}
+
+	if n, err := comfyent.CountUsers(ctx, client, user.NameContains("ali")); err != nil || n != 2 {
+		t.Fatalf("got %d users and error %v, want 2", n, err)
+	}
+	if n, err := comfyent.CountUsers(ctx, client); err != nil || n != 3 {
+		t.Fatalf("got %d users and error %v without predicates, want 3", n, err)
+	}
+}
@@ -0,0 +1,58 @@
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// ExplainQuery returns SQLite's plan for query, which is not run. query must come from a client
+// built on driver.NewCaptureDriver, the only way to get the SQL ent builds for it.
+func ExplainQuery(ctx context.Context, db *sql.DB, query *ent.UserQuery) (string, error) {
+	cctx, captured := driver.WithCapture(ctx)
+	_, err := query.Clone().All(cctx)
+	switch {
+	case err == nil:
+		return "", errors.New("explaining query: not captured, build the client on driver.NewCaptureDriver")
+	case !errors.Is(err, driver.ErrCaptured):
+		return "", fmt.Errorf("explaining query: %w", err)
+	}
+	return Explain(ctx, db, captured.Query, captured.Args...)
+}
+
+// Explain returns the output of EXPLAIN QUERY PLAN for query, one step per line, indented
+// under its parent step like the sqlite3 shell does:
+//
+//	SEARCH users USING INDEX users_email_key (email=?)
+func Explain(ctx context.Context, db *sql.DB, query string, args ...any) (string, error) {
+	rows, err := db.QueryContext(ctx, "EXPLAIN QUERY PLAN "+query, args...)
+	if err != nil {
+		return "", fmt.Errorf("explaining query: %w", err)
+	}
+	defer rows.Close()
+	var (
+		plan  strings.Builder
+		depth = map[int]int{}
+	)
+	for rows.Next() {
+		var (
+			id, parent, notUsed int
+			detail              string
+		)
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return "", fmt.Errorf("explaining query: %w", err)
+		}
+		// Steps at the top have parent 0, which is never an id.
+		depth[id] = depth[parent] + 1
+		fmt.Fprintf(&plan, "%s%s\n", strings.Repeat("  ", depth[id]-1), detail)
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("explaining query: %w", err)
+	}
+	return plan.String(), nil
+}
@@ -0,0 +1,45 @@
+package comfyent_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestExplainQuery(t *testing.T) {
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	db := comfyent.OpenDB(comfy, comfylite3.WithForeignKeys())
+	client := ent.NewClient(ent.Driver(driver.NewCaptureDriver(sql.OpenDB(dialect.SQLite, db))))
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := comfyent.ExplainQuery(ctx, db, client.User.Query().Where(user.NameContains("ada")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(plan, "SCAN users") {
+		t.Fatalf("got plan %q, want a scan of users for a substring match", plan)
+	}
+	plan, err = comfyent.ExplainQuery(ctx, db, client.User.Query().Where(user.Email("ada@example.com")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(plan, "SEARCH users USING INDEX users_email_key") {
+		t.Fatalf("got plan %q, want the email index used", plan)
+	}
+}
@@ -0,0 +1,154 @@
+package comfyent
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// exportBatchSize is the number of users loaded at once by the export helpers.
+const exportBatchSize = 500
+
+// ExportUsersJSON writes every user to w as a JSON array, in ID order.
+// Users are loaded in batches and written as they come, so the whole table is never held in memory.
+// The output can be loaded back with seed.SeedFromJSON.
+func ExportUsersJSON(ctx context.Context, client *ent.Client, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	first := true
+	for cursor := 0; ; {
+		batch, next, err := PageUsers(ctx, client, cursor, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, u := range batch {
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			data, err := json.Marshal(u)
+			if err != nil {
+				return fmt.Errorf("encoding user %d: %w", u.ID, err)
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+// csvColumn is a column ExportUsersCSV can write.
+type csvColumn struct {
+	name  string
+	value func(*ent.User) string
+}
+
+// csvValues format the columns of a user for ExportUsersCSV. Optional columns are left empty
+// when unset.
+var csvValues = map[string]func(*ent.User) string{
+	user.FieldID:        func(u *ent.User) string { return strconv.Itoa(u.ID) },
+	user.FieldCreatedAt: func(u *ent.User) string { return u.CreatedAt.Format(time.RFC3339Nano) },
+	user.FieldUpdatedAt: func(u *ent.User) string { return u.UpdatedAt.Format(time.RFC3339Nano) },
+	user.FieldDeletedAt: func(u *ent.User) string {
+		if u.DeletedAt == nil {
+			return ""
+		}
+		return u.DeletedAt.Format(time.RFC3339Nano)
+	},
+	user.FieldName:    func(u *ent.User) string { return u.Name },
+	user.FieldAge:     func(u *ent.User) string { return strconv.Itoa(u.Age) },
+	user.FieldEmail:   func(u *ent.User) string { return u.Email },
+	user.FieldRole:    func(u *ent.User) string { return string(u.Role) },
+	user.FieldVersion: func(u *ent.User) string { return strconv.Itoa(u.Version) },
+	user.FieldMetadata: func(u *ent.User) string {
+		if u.Metadata == nil {
+			return ""
+		}
+		// A map of JSON values always encodes.
+		data, _ := json.Marshal(u.Metadata)
+		return string(data)
+	},
+	user.FieldActive:   func(u *ent.User) string { return strconv.FormatBool(u.Active) },
+	user.FieldTenantID: func(u *ent.User) string { return u.TenantID },
+}
+
+// csvColumns are the columns ExportUsersCSV accepts, every column of the users table, in the
+// order of user.Columns, which is the one it writes them in by default.
+var csvColumns = func() []csvColumn {
+	cols := make([]csvColumn, len(user.Columns))
+	for i, name := range user.Columns {
+		value, ok := csvValues[name]
+		if !ok {
+			panic(fmt.Sprintf("comfyent: no CSV format for user column %q", name))
+		}
+		cols[i] = csvColumn{name, value}
+	}
+	return cols
+}()
+
+// ExportUsersCSV writes every user to w as CSV, in ID order: a header naming columns, then one
+// row per user with those columns only, or with all of csvColumns if columns is empty.
+// Unknown columns are rejected upfront. Like ExportUsersJSON it loads users in batches.
+// An export of name, age and email can be loaded back with ImportUsersCSV.
+func ExportUsersCSV(ctx context.Context, client *ent.Client, w io.Writer, columns []string) error {
+	cols := csvColumns
+	if len(columns) > 0 {
+		cols = make([]csvColumn, len(columns))
+		for i, name := range columns {
+			j := slices.IndexFunc(csvColumns, func(c csvColumn) bool { return c.name == name })
+			if j < 0 {
+				return fmt.Errorf("cannot export unknown column %q", name)
+			}
+			cols[i] = csvColumns[j]
+		}
+	}
+	cw := csv.NewWriter(w)
+	record := make([]string, len(cols))
+	for i, c := range cols {
+		record[i] = c.name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for cursor := 0; ; {
+		batch, next, err := PageUsers(ctx, client, cursor, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, u := range batch {
+			for i, c := range cols {
+				record[i] = c.value(u)
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		// Flush once per batch, so rows reach w as they are loaded.
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	return nil
+}
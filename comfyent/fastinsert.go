@@ -0,0 +1,52 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// fastInsertBatchSize is the number of users committed per transaction by FastInsertUsers
+// when no batch size is given.
+const fastInsertBatchSize = 5000
+
+// FastInsertUsers inserts users much faster than creating them one by one, committing a
+// transaction every batchSize users (fastInsertBatchSize when batchSize is 0 or less) instead of
+// one per row. Within a transaction, users are sent upsertBatchSize per multi-row INSERT, which
+// is prepared once and reused for every full chunk of the batch when the client is on OpenDB.
+// Schema hooks still run, so emails are normalized and creations audited.
+//
+// Batches committed before a failure are kept: the returned error tells how many users were
+// inserted.
+func FastInsertUsers(ctx context.Context, client *ent.Client, users []UserInput, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = fastInsertBatchSize
+	}
+	for start := 0; start < len(users); start += batchSize {
+		if err := insertBatch(ctx, client, users[start:min(start+batchSize, len(users))]); err != nil {
+			return fmt.Errorf("inserting users after %d were committed: %w", start, err)
+		}
+	}
+	return nil
+}
+
+func insertBatch(ctx context.Context, client *ent.Client, users []UserInput) error {
+	ctx = withPrepared(ctx)
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(users); start += upsertBatchSize {
+		chunk := users[start:min(start+upsertBatchSize, len(users))]
+		builders := make([]*ent.UserCreate, len(chunk))
+		for i, in := range chunk {
+			builders[i] = in.builder(tx.Client())
+		}
+		if err := tx.User.CreateBulk(builders...).Exec(ctx); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,62 @@
+package comfyent_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+// benchUsers is the number of users inserted per benchmark operation.
+const benchUsers = 1000
+
+// userInputs returns n users whose emails are unique for a given prefix.
+func userInputs(prefix string, n int) []comfyent.UserInput {
+	users := make([]comfyent.UserInput, n)
+	for i := range users {
+		users[i] = comfyent.UserInput{Name: fmt.Sprintf("user%d", i), Age: 20 + i%50, Email: fmt.Sprintf("%s-%d@example.com", prefix, i)}
+	}
+	return users
+}
+
+func TestFastInsertUsers(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// 4 commits, the last one partial.
+	if err := comfyent.FastInsertUsers(ctx, client, userInputs("u", 10000), 3000); err != nil {
+		t.Fatalf("FastInsertUsers: %v", err)
+	}
+	if n := client.User.Query().CountX(ctx); n != 10000 {
+		t.Fatalf("got %d users, want 10000", n)
+	}
+}
+
+func BenchmarkFastInsertUsers(b *testing.B) {
+	client, cleanup := comfyenttest.NewTestClient(b)
+	defer cleanup()
+	ctx := context.Background()
+
+	for i := 0; i < b.N; i++ {
+		if err := comfyent.FastInsertUsers(ctx, client, userInputs(fmt.Sprint(i), benchUsers), 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCreateOneByOne(b *testing.B) {
+	client, cleanup := comfyenttest.NewTestClient(b)
+	defer cleanup()
+	ctx := context.Background()
+
+	for i := 0; i < b.N; i++ {
+		for _, u := range userInputs(fmt.Sprint(i), benchUsers) {
+			if err := client.User.Create().SetName(u.Name).SetAge(u.Age).SetEmail(u.Email).Exec(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrForeignKeysDisabled is returned by AssertForeignKeysEnabled when foreign keys aren't enforced.
+var ErrForeignKeysDisabled = errors.New("foreign key enforcement is disabled")
+
+// AssertForeignKeysEnabled reads PRAGMA foreign_keys back and fails if enforcement is off,
+// which happens silently when the pragma was applied to another pooled connection
+// or the SQLite build ignores it.
+func AssertForeignKeysEnabled(db *sql.DB) error {
+	var enabled int
+	if err := db.QueryRow("PRAGMA foreign_keys;").Scan(&enabled); err != nil {
+		return fmt.Errorf("reading foreign_keys: %w", err)
+	}
+	if enabled == 0 {
+		return ErrForeignKeysDisabled
+	}
+	return nil
+}
+
+// ForeignKeyViolation is a row, found by PRAGMA foreign_key_check, referencing a missing parent.
+type ForeignKeyViolation struct {
+	Table  string
+	RowID  int64
+	Parent string
+}
+
+// ForeignKeyViolationsError is returned by ImportWithFKDisabled when the imported data left
+// dangling references.
+type ForeignKeyViolationsError struct {
+	Violations []ForeignKeyViolation
+}
+
+func (e *ForeignKeyViolationsError) Error() string {
+	v := e.Violations[0]
+	return fmt.Sprintf("%d foreign key violations, first in %s row %d referencing %s", len(e.Violations), v.Table, v.RowID, v.Parent)
+}
+
+// ImportWithFKDisabled turns foreign key enforcement off while fn runs, so related rows can be
+// loaded in any order, then checks the whole database with PRAGMA foreign_key_check and
+// restores enforcement. Violations are reported as a *ForeignKeyViolationsError.
+//
+// SQLite ignores the pragma inside a transaction: fn may open its own, but ImportWithFKDisabled
+// must not be called from one.
+//
+// comfylite3 runs every statement on a single shared connection, so enforcement is off for
+// every caller of the process using that database while fn runs, not only for fn: run imports
+// when nothing else writes, e.g. during a maintenance window.
+func ImportWithFKDisabled(ctx context.Context, db *sql.DB, fn func() error) (err error) {
+	var enabled int
+	if err := db.QueryRowContext(ctx, "PRAGMA foreign_keys;").Scan(&enabled); err != nil {
+		return fmt.Errorf("reading foreign_keys: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, "PRAGMA foreign_keys = OFF;"); err != nil {
+		return fmt.Errorf("disabling foreign keys: %w", err)
+	}
+	defer func() {
+		// The import is over even if ctx was canceled, enforcement must come back regardless.
+		if _, rerr := db.ExecContext(context.WithoutCancel(ctx), fmt.Sprintf("PRAGMA foreign_keys = %d;", enabled)); rerr != nil && err == nil {
+			err = fmt.Errorf("restoring foreign keys: %w", rerr)
+		}
+	}()
+	if err := fn(); err != nil {
+		return err
+	}
+	violations, err := foreignKeyCheck(ctx, db)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return &ForeignKeyViolationsError{Violations: violations}
+	}
+	return nil
+}
+
+func foreignKeyCheck(ctx context.Context, db *sql.DB) ([]ForeignKeyViolation, error) {
+	rows, err := db.QueryContext(ctx, "PRAGMA foreign_key_check;")
+	if err != nil {
+		return nil, fmt.Errorf("checking foreign keys: %w", err)
+	}
+	defer rows.Close()
+	var violations []ForeignKeyViolation
+	for rows.Next() {
+		var (
+			v     ForeignKeyViolation
+			rowID sql.NullInt64
+			fkID  int
+		)
+		if err := rows.Scan(&v.Table, &rowID, &v.Parent, &fkID); err != nil {
+			return nil, fmt.Errorf("checking foreign keys: %w", err)
+		}
+		v.RowID = rowID.Int64
+		violations = append(violations, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("checking foreign keys: %w", err)
+	}
+	return violations, nil
+}
@@ -0,0 +1,72 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestAssertForeignKeysEnabled(t *testing.T) {
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+
+	db := comfyent.OpenDB(comfy)
+	if err := comfyent.AssertForeignKeysEnabled(db); !errors.Is(err, comfyent.ErrForeignKeysDisabled) {
+		t.Fatalf("got error %v without foreign keys, want ErrForeignKeysDisabled", err)
+	}
+	db = comfyent.OpenDB(comfy, comfylite3.WithForeignKeys())
+	if err := comfyent.AssertForeignKeysEnabled(db); err != nil {
+		t.Fatalf("got error %v with foreign keys, want nil", err)
+	}
+}
+
+func TestImportWithFKDisabled(t *testing.T) {
+	db := comfyent.OpenDB(newFileComfy(t), comfylite3.WithForeignKeys())
+	defer db.Close()
+	ctx := context.Background()
+	for _, ddl := range []string{
+		"CREATE TABLE teams (id INTEGER PRIMARY KEY);",
+		"CREATE TABLE members (id INTEGER PRIMARY KEY, team_id INTEGER NOT NULL REFERENCES teams (id));",
+	} {
+		if _, err := db.ExecContext(ctx, ddl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, "INSERT INTO members (id, team_id) VALUES (1, 1);"); err == nil {
+		t.Fatal("inserted a member of a missing team with foreign keys on")
+	}
+
+	// Children first, then their parents.
+	err := comfyent.ImportWithFKDisabled(ctx, db, func() error {
+		if _, err := db.ExecContext(ctx, "INSERT INTO members (id, team_id) VALUES (1, 1), (2, 1);"); err != nil {
+			return err
+		}
+		_, err := db.ExecContext(ctx, "INSERT INTO teams (id) VALUES (1);")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("importing out of order: %v", err)
+	}
+	if err := comfyent.AssertForeignKeysEnabled(db); err != nil {
+		t.Fatalf("after the import: %v", err)
+	}
+
+	// Dangling references are reported once fn returns.
+	err = comfyent.ImportWithFKDisabled(ctx, db, func() error {
+		_, err := db.ExecContext(ctx, "INSERT INTO members (id, team_id) VALUES (3, 2);")
+		return err
+	})
+	var verr *comfyent.ForeignKeyViolationsError
+	if !errors.As(err, &verr) || len(verr.Violations) != 1 || verr.Violations[0].Table != "members" || verr.Violations[0].RowID != 3 {
+		t.Fatalf("got %v, want the violation of member 3", err)
+	}
+	if err := comfyent.AssertForeignKeysEnabled(db); err != nil {
+		t.Fatalf("after the failed import: %v", err)
+	}
+}
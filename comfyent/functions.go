@@ -0,0 +1,68 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/mattn/go-sqlite3"
+)
+
+// RegisterFunctions adds the SQL functions SQLite lacks to conn: regexp, which backs the
+// REGEXP operator with Go's regexp package, see user.NameMatchesRegexp.
+func RegisterFunctions(conn *sqlite3.SQLiteConn) error {
+	// "x REGEXP y" calls regexp(y, x).
+	if err := conn.RegisterFunc("regexp", matchRegexp, true); err != nil {
+		return fmt.Errorf("registering regexp: %w", err)
+	}
+	return nil
+}
+
+// InstallFunctions registers the functions of RegisterFunctions on the connection of comfy.
+// comfylite3 keeps a single connection open, so this is needed once, right after
+// comfylite3.New; Open does it.
+func InstallFunctions(ctx context.Context, comfy *comfylite3.ComfyDB) error {
+	conn, err := comfy.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("installing functions: %w", err)
+	}
+	// The connection must be released for comfylite3 to run anything else.
+	defer conn.Close()
+	return conn.Raw(func(driverConn any) error {
+		sc, ok := driverConn.(*sqlite3.SQLiteConn)
+		if !ok {
+			return fmt.Errorf("installing functions: unexpected connection type %T", driverConn)
+		}
+		return RegisterFunctions(sc)
+	})
+}
+
+// regexps caches compiled patterns, as regexp is called once per row.
+var regexps struct {
+	sync.Mutex
+	m map[string]*regexp.Regexp
+}
+
+// maxRegexps bounds the cache, which is simply emptied when full.
+const maxRegexps = 64
+
+func matchRegexp(pattern, s string) (bool, error) {
+	regexps.Lock()
+	re, ok := regexps.m[pattern]
+	regexps.Unlock()
+	if !ok {
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			return false, err
+		}
+		regexps.Lock()
+		if regexps.m == nil || len(regexps.m) >= maxRegexps {
+			regexps.m = make(map[string]*regexp.Regexp)
+		}
+		regexps.m[pattern] = re
+		regexps.Unlock()
+	}
+	return re.MatchString(s), nil
+}
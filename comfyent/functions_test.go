@@ -0,0 +1,38 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestNameMatchesRegexp(t *testing.T) {
+	// Open installs the regexp function.
+	client, comfy, err := comfyent.Open(comfyent.Config{InMemory: true, ForeignKeys: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"ada", "bob", "Eve", "xyz"} {
+		client.User.Create().SetName(name).SetAge(30).SetEmail(name + "@example.com").ExecX(ctx)
+	}
+
+	names := client.User.Query().
+		Where(user.NameMatchesRegexp(`^(?i)[aeiou]`)).
+		Order(user.ByName()).
+		Select(user.FieldName).
+		StringsX(ctx)
+	if len(names) != 2 || names[0] != "Eve" || names[1] != "ada" {
+		t.Fatalf("got %q, want the names starting with a vowel", names)
+	}
+	if _, err := client.User.Query().Where(user.NameMatchesRegexp(`(`)).All(ctx); err == nil {
+		t.Fatal("queried with an invalid pattern")
+	}
+}
@@ -0,0 +1,43 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// GetOrCreateUser returns the user with the email of input, creating it from input when there's
+// none. The boolean reports whether the user was created. Concurrent calls for the same email
+// create a single user: the callers losing the race get the winner's user back.
+//
+// It fails with ErrEmailTaken when the email belongs to a user the query can't see, such as a
+// soft-deleted one: that user is neither returned nor created anew.
+func GetOrCreateUser(ctx context.Context, client *ent.Client, input UserInput) (*ent.User, bool, error) {
+	byEmail := client.User.Query().Where(user.Email(schema.NormalizeEmail(input.Email)))
+	u, err := byEmail.Only(ctx)
+	switch {
+	case err == nil:
+		return u, false, nil
+	case !ent.IsNotFound(err):
+		return nil, false, fmt.Errorf("looking up user: %w", err)
+	}
+	u, err = input.builder(client).Save(ctx)
+	if err == nil {
+		return u, true, nil
+	}
+	if field, ok := IsUniqueViolation(err); !ok || field != user.FieldEmail {
+		return nil, false, fmt.Errorf("creating user: %w", err)
+	}
+	// Someone else created it since the lookup.
+	u, qerr := byEmail.Clone().Only(ctx)
+	switch {
+	case ent.IsNotFound(qerr):
+		return nil, false, fmt.Errorf("creating user %s: %w", input.Email, ErrEmailTaken)
+	case qerr != nil:
+		return nil, false, fmt.Errorf("creating user: %w", err)
+	}
+	return u, false, nil
+}
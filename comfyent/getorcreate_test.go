@@ -0,0 +1,66 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+func TestGetOrCreateUserConcurrent(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	const callers = 8
+	var (
+		wg      sync.WaitGroup
+		users   = make([]*ent.User, callers)
+		created = make([]bool, callers)
+		errs    = make([]error, callers)
+	)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			users[i], created[i], errs[i] = comfyent.GetOrCreateUser(ctx, client, comfyent.UserInput{Name: "a", Age: 30, Email: "a@example.com"})
+		}()
+	}
+	wg.Wait()
+	creators := 0
+	for i := range callers {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: %v", i, errs[i])
+		}
+		if users[i].ID != users[0].ID {
+			t.Fatalf("caller %d got user %d, want %d as the others", i, users[i].ID, users[0].ID)
+		}
+		if created[i] {
+			creators++
+		}
+	}
+	if creators != 1 {
+		t.Fatalf("%d callers created the user, want 1", creators)
+	}
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users, want 1", n)
+	}
+}
+
+func TestGetOrCreateUserSoftDeleted(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	client.User.DeleteOne(u).ExecX(ctx)
+
+	_, _, err := comfyent.GetOrCreateUser(ctx, client, comfyent.UserInput{Name: "a", Age: 30, Email: "a@example.com"})
+	if !errors.Is(err, comfyent.ErrEmailTaken) {
+		t.Fatalf("got %v for the email of a deleted user, want ErrEmailTaken", err)
+	}
+}
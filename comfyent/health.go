@@ -0,0 +1,45 @@
+package comfyent
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// healthTimeout bounds the ping run by HealthHandler.
+const healthTimeout = 2 * time.Second
+
+// healthStatus is the JSON body written by HealthHandler.
+type healthStatus struct {
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+	OpenConns    int    `json:"open_conns"`
+	InUse        int    `json:"in_use"`
+	Idle         int    `json:"idle"`
+	WaitCount    int64  `json:"wait_count"`
+	WaitDuration string `json:"wait_duration"`
+}
+
+// HealthHandler serves liveness and readiness probes: it pings db and answers 200 with
+// {"status":"ok",...} when it responds within a couple of seconds, 503 with
+// {"status":"unavailable",...} otherwise. The body also reports the pool counters of db.Stats().
+func HealthHandler(db *sql.DB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, code := healthStatus{Status: "ok"}, http.StatusOK
+		if err := Ping(r.Context(), db, healthTimeout); err != nil {
+			status.Status, status.Error, code = "unavailable", err.Error(), http.StatusServiceUnavailable
+		}
+		stats := db.Stats()
+		status.OpenConns = stats.OpenConnections
+		status.InUse = stats.InUse
+		status.Idle = stats.Idle
+		status.WaitCount = stats.WaitCount
+		status.WaitDuration = stats.WaitDuration.String()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(status)
+	})
+}
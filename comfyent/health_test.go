@@ -0,0 +1,35 @@
+package comfyent_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestHealthHandler(t *testing.T) {
+	db := comfyent.OpenDB(newFileComfy(t))
+	handler := comfyent.HealthHandler(db)
+	probe := func() (int, map[string]any) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		var body map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decoding %q: %v", rec.Body.String(), err)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Fatalf("got Content-Type %q, want application/json", ct)
+		}
+		return rec.Code, body
+	}
+
+	if code, body := probe(); code != http.StatusOK || body["status"] != "ok" {
+		t.Fatalf("got %d %v, want 200 ok", code, body)
+	}
+	db.Close()
+	if code, body := probe(); code != http.StatusServiceUnavailable || body["status"] != "unavailable" || body["error"] == nil {
+		t.Fatalf("got %d %v on a closed database, want 503 unavailable with the error", code, body)
+	}
+}
@@ -0,0 +1,40 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// AgeHistogram counts users per age bucket of bucketSize years, keyed by the lower bound
+// of the bucket: with a bucketSize of 10, key 30 counts the users aged 30 to 39.
+// SQLite computes the buckets and groups by them, only one row per bucket is loaded.
+func AgeHistogram(ctx context.Context, client *ent.Client, bucketSize int) (map[int]int, error) {
+	if bucketSize < 1 {
+		return nil, fmt.Errorf("bucket size must be positive, got %d", bucketSize)
+	}
+	var buckets []struct {
+		Bucket int `sql:"bucket"`
+		Count  int `sql:"count"`
+	}
+	err := client.User.Query().
+		Modify(func(s *sql.Selector) {
+			bucket := sql.ExprP(fmt.Sprintf("(%s / ?) * ?", s.C(user.FieldAge)), bucketSize, bucketSize)
+			s.Select().
+				AppendSelectExprAs(bucket, "bucket").
+				AppendSelectExprAs(sql.Expr(sql.Count("*")), "count").
+				GroupBy("bucket")
+		}).
+		Scan(ctx, &buckets)
+	if err != nil {
+		return nil, fmt.Errorf("computing age histogram: %w", err)
+	}
+	histogram := make(map[int]int, len(buckets))
+	for _, b := range buckets {
+		histogram[b.Bucket] = b.Count
+	}
+	return histogram, nil
+}
@@ -0,0 +1,37 @@
+package comfyent_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestAgeHistogram(t *testing.T) {
+	client, log := newRecordingClient(t)
+	ctx := context.Background()
+	for i, age := range []int{5, 9, 10, 19, 19, 35, 150} {
+		client.User.Create().SetName("u").SetAge(age).SetEmail(fmt.Sprintf("u%d@example.com", i)).ExecX(ctx)
+	}
+
+	n := log.Len()
+	got, err := comfyent.AgeHistogram(ctx, client, 10)
+	if err != nil {
+		t.Fatalf("AgeHistogram: %v", err)
+	}
+	// The users are grouped by bucket in SQL, not by age.
+	if stmts := log.Since(n, "SELECT"); len(stmts) != 1 || !strings.Contains(stmts[0], "GROUP BY `bucket`") {
+		t.Fatalf("got statements %q, want a single SELECT grouping by bucket", stmts)
+	}
+	want := map[int]int{0: 2, 10: 3, 30: 1, 150: 1}
+	if !maps.Equal(got, want) {
+		t.Fatalf("got buckets %v, want %v", got, want)
+	}
+
+	if _, err := comfyent.AgeHistogram(ctx, client, 0); err == nil {
+		t.Fatal("AgeHistogram succeeded with a zero bucket size")
+	}
+}
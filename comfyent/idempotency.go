@@ -0,0 +1,59 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/idempotencykey"
+)
+
+// CreateUserIdempotent creates the user described by input, unless a call with the same key
+// already did, in which case that user is returned instead. Clients retrying a create, e.g.
+// after a timeout, pass the same key to make sure a single user is created.
+//
+// The user and its key are saved in one transaction, so a key never outlives a failed create.
+func CreateUserIdempotent(ctx context.Context, client *ent.Client, key string, input UserInput) (*ent.User, error) {
+	u, err := createUserIdempotent(ctx, client, key, input)
+	if field, ok := IsUniqueViolation(err); ok && field == idempotencykey.FieldKey {
+		// A concurrent call with the same key committed first.
+		u, err = userByIdempotencyKey(ctx, client, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating user with idempotency key %q: %w", key, err)
+	}
+	return u, nil
+}
+
+func createUserIdempotent(ctx context.Context, client *ent.Client, key string, input UserInput) (u *ent.User, err error) {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	u, err = userByIdempotencyKey(ctx, tx.Client(), key)
+	if err == nil {
+		return u, tx.Commit()
+	}
+	if !ent.IsNotFound(err) {
+		return nil, err
+	}
+	if u, err = input.builder(tx.Client()).Save(ctx); err != nil {
+		return nil, err
+	}
+	if err := tx.IdempotencyKey.Create().SetKey(key).SetUser(u).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return u, tx.Commit()
+}
+
+func userByIdempotencyKey(ctx context.Context, client *ent.Client, key string) (*ent.User, error) {
+	return client.IdempotencyKey.Query().
+		Where(idempotencykey.Key(key)).
+		QueryUser().
+		Only(ctx)
+}
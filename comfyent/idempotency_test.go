@@ -0,0 +1,41 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestCreateUserIdempotent(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	input := comfyent.UserInput{Name: "a", Age: 30, Email: "a@example.com"}
+
+	first, err := comfyent.CreateUserIdempotent(ctx, client, "req-1", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A retry, even with different input, gets the first user back.
+	input.Name = "b"
+	retry, err := comfyent.CreateUserIdempotent(ctx, client, "req-1", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retry.ID != first.ID || retry.Name != "a" {
+		t.Fatalf("got user %d named %s on retry, want user %d named a", retry.ID, retry.Name, first.ID)
+	}
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users, want 1", n)
+	}
+
+	other, err := comfyent.CreateUserIdempotent(ctx, client, "req-2", comfyent.UserInput{Name: "c", Age: 30, Email: "c@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.ID == first.ID {
+		t.Fatal("got the first user for another key")
+	}
+}
@@ -0,0 +1,181 @@
+package comfyent
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// importBatchSize is the number of rows inserted per CreateBulk statement by ImportUsersCSV.
+const importBatchSize = 500
+
+type importOptions struct {
+	strict bool
+}
+
+// ImportOption configures ImportUsersCSV.
+type ImportOption func(*importOptions)
+
+// WithStrict makes ImportUsersCSV stop at the first malformed row instead of skipping it.
+func WithStrict() ImportOption {
+	return func(o *importOptions) {
+		o.strict = true
+	}
+}
+
+// RowError reports a CSV row that couldn't be imported.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+// ImportError lists every row rejected by ImportUsersCSV.
+type ImportError struct {
+	Rows []RowError
+}
+
+func (e *ImportError) Error() string {
+	msgs := make([]string, len(e.Rows))
+	for i, row := range e.Rows {
+		msgs[i] = row.Error()
+	}
+	return fmt.Sprintf("%d invalid rows: %s", len(e.Rows), strings.Join(msgs, "; "))
+}
+
+func (e *ImportError) Unwrap() []error {
+	errs := make([]error, len(e.Rows))
+	for i, row := range e.Rows {
+		errs[i] = row
+	}
+	return errs
+}
+
+// ImportUsersCSV inserts the users read from r, which must start with a name,age,email header
+// (in any order). Rows are inserted in batches of importBatchSize.
+//
+// Malformed rows are skipped and reported together in an *ImportError once the whole input
+// was read, unless WithStrict is passed. It returns the number of users inserted.
+func ImportUsersCSV(ctx context.Context, client *ent.Client, r io.Reader, opts ...ImportOption) (int, error) {
+	cfg := importOptions{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	cr := csv.NewReader(r)
+	// Short or long rows are reported like any other malformed row.
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return 0, fmt.Errorf("reading CSV header: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{user.FieldName, user.FieldAge, user.FieldEmail} {
+		if _, ok := cols[name]; !ok {
+			return 0, fmt.Errorf("CSV header is missing the %q column", name)
+		}
+	}
+
+	var (
+		inserted int
+		invalid  []RowError
+		batch    []*ent.UserCreate
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := client.User.CreateBulk(batch...).Exec(ctx); err != nil {
+			return fmt.Errorf("inserting users: %w", err)
+		}
+		inserted += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var line int
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			line = perr.StartLine
+		} else {
+			line, _ = cr.FieldPos(0)
+		}
+		if err == nil {
+			var create *ent.UserCreate
+			if create, err = parseUserRecord(client, record, cols); err == nil {
+				batch = append(batch, create)
+			}
+		}
+		if err != nil {
+			rowErr := RowError{Line: line, Err: err}
+			if cfg.strict {
+				return inserted, rowErr
+			}
+			invalid = append(invalid, rowErr)
+			continue
+		}
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return inserted, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return inserted, err
+	}
+	if len(invalid) > 0 {
+		return inserted, &ImportError{Rows: invalid}
+	}
+	return inserted, nil
+}
+
+// parseUserRecord turns a CSV record into a create builder, running the schema validators
+// upfront so a bad row doesn't fail the whole batch.
+func parseUserRecord(client *ent.Client, record []string, cols map[string]int) (*ent.UserCreate, error) {
+	field := func(name string) string {
+		if i := cols[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	name, email := field(user.FieldName), field(user.FieldEmail)
+	age, err := strconv.Atoi(field(user.FieldAge))
+	if err != nil {
+		return nil, &ValidationError{Field: user.FieldAge, Reason: fmt.Sprintf("%q is not an integer", field(user.FieldAge))}
+	}
+	if err := user.NameValidator(name); err != nil {
+		return nil, &ValidationError{Field: user.FieldName, Reason: err.Error()}
+	}
+	if err := user.AgeValidator(age); err != nil {
+		return nil, &ValidationError{Field: user.FieldAge, Reason: err.Error()}
+	}
+	if err := user.EmailValidator(email); err != nil {
+		return nil, &ValidationError{Field: user.FieldEmail, Reason: err.Error()}
+	}
+	return client.User.Create().
+		SetName(name).
+		SetAge(age).
+		SetEmail(email), nil
+}
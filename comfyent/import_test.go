@@ -0,0 +1,92 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestImportUsersCSV(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	var b strings.Builder
+	b.WriteString("email,name,age\n")
+	for i := 0; i < 1200; i++ {
+		fmt.Fprintf(&b, "user%d@example.com,user%d,%d\n", i, i, 20+i%50)
+	}
+	n, err := comfyent.ImportUsersCSV(ctx, client, strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("ImportUsersCSV: %v", err)
+	}
+	if n != 1200 {
+		t.Fatalf("got %d users imported, want 1200", n)
+	}
+	if count := client.User.Query().CountX(ctx); count != 1200 {
+		t.Fatalf("got %d users, want 1200", count)
+	}
+}
+
+const badRowsCSV = `name,age,email
+Alice,30,alice@example.com
+Bob,thirty,bob@example.com
+Charlie,35,charlie@example.com
+,28,david@example.com
+Eve,40,eve@example.com
+`
+
+func TestImportUsersCSVBadRows(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	n, err := comfyent.ImportUsersCSV(ctx, client, strings.NewReader(badRowsCSV))
+	if n != 3 {
+		t.Fatalf("got %d users imported, want 3", n)
+	}
+	var ierr *comfyent.ImportError
+	if !errors.As(err, &ierr) {
+		t.Fatalf("got error %v, want an *ImportError", err)
+	}
+	if len(ierr.Rows) != 2 || ierr.Rows[0].Line != 3 || ierr.Rows[1].Line != 5 {
+		t.Fatalf("got rejected rows %v, want lines 3 and 5", ierr.Rows)
+	}
+	verr, ok := comfyent.AsValidationError(ierr.Rows[0])
+	if !ok || verr.Field != "age" {
+		t.Fatalf("got %v for line 3, want a validation error of age", ierr.Rows[0])
+	}
+	if count := client.User.Query().CountX(ctx); count != 3 {
+		t.Fatalf("got %d users, want 3", count)
+	}
+}
+
+func TestImportUsersCSVStrict(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	n, err := comfyent.ImportUsersCSV(ctx, client, strings.NewReader(badRowsCSV), comfyent.WithStrict())
+	var rerr comfyent.RowError
+	if !errors.As(err, &rerr) || rerr.Line != 3 {
+		t.Fatalf("got error %v, want line 3 rejected", err)
+	}
+	if n != 0 {
+		t.Fatalf("got %d users imported, want 0 as the batch wasn't flushed", n)
+	}
+}
+
+func TestImportUsersCSVMissingColumn(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+
+	_, err := comfyent.ImportUsersCSV(context.Background(), client, strings.NewReader("name,age\nAlice,30\n"))
+	if err == nil || !strings.Contains(err.Error(), `"email"`) {
+		t.Fatalf("got error %v, want the missing email column reported", err)
+	}
+}
@@ -0,0 +1,148 @@
+package comfyent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// ErrIngestorClosed is returned when adding users to a closed Ingestor.
+var ErrIngestorClosed = errors.New("ingestor is closed")
+
+// Ingestor buffers users and creates them in bulk, once size of them are waiting or every
+// interval, whichever comes first, which is much faster than creating them one at a time.
+// It is safe for concurrent use.
+//
+// Users are lost if the process exits before they are flushed: Close the ingestor on shutdown.
+type Ingestor struct {
+	client *ent.Client
+	size   int
+
+	mu     sync.Mutex
+	buf    []UserInput
+	errs   []error
+	closed bool
+
+	// flushMu keeps flushes in order.
+	flushMu sync.Mutex
+	full    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewIngestor returns an ingestor creating the users added to it in client, in batches of size,
+// and starts its background flushes. A zero interval only flushes once size users are waiting.
+func NewIngestor(client *ent.Client, size int, interval time.Duration) (*Ingestor, error) {
+	if interval < 0 {
+		return nil, fmt.Errorf("flush interval must not be negative, got %v", interval)
+	}
+	in := &Ingestor{
+		client: client,
+		size:   max(size, 1),
+		full:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go in.run(interval)
+	return in, nil
+}
+
+func (in *Ingestor) run(interval time.Duration) {
+	defer close(in.done)
+	// A nil channel never ticks.
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-in.stop:
+			return
+		case <-tick:
+		case <-in.full:
+		}
+		in.Flush(context.Background())
+	}
+}
+
+// Add buffers u, to be created by the next flush. It fails with ErrIngestorClosed once Close
+// was called.
+func (in *Ingestor) Add(u UserInput) error {
+	in.mu.Lock()
+	if in.closed {
+		in.mu.Unlock()
+		return ErrIngestorClosed
+	}
+	in.buf = append(in.buf, u)
+	full := len(in.buf) >= in.size
+	in.mu.Unlock()
+	if full {
+		select {
+		case in.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Flush creates the users buffered so far, size at a time. A failed chunk doesn't stop the
+// others, but its users are dropped: the error, also kept for Err, joins those of every failed
+// chunk and tells how many users were dropped in total.
+func (in *Ingestor) Flush(ctx context.Context) error {
+	in.flushMu.Lock()
+	defer in.flushMu.Unlock()
+	in.mu.Lock()
+	buf := in.buf
+	in.buf = nil
+	in.mu.Unlock()
+	var (
+		errs    []error
+		dropped int
+	)
+	for start := 0; start < len(buf); start += in.size {
+		chunk := buf[start:min(start+in.size, len(buf))]
+		builders := make([]*ent.UserCreate, len(chunk))
+		for i, u := range chunk {
+			builders[i] = u.builder(in.client)
+		}
+		if err := in.client.User.CreateBulk(builders...).Exec(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("ingesting %d users: %w", len(chunk), err))
+			dropped += len(chunk)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	err := fmt.Errorf("flushing %d users, %d dropped: %w", len(buf), dropped, errors.Join(errs...))
+	in.mu.Lock()
+	in.errs = append(in.errs, err)
+	in.mu.Unlock()
+	return err
+}
+
+// Err returns the errors of every failed flush so far, joined.
+func (in *Ingestor) Err() error {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return errors.Join(in.errs...)
+}
+
+// Close stops the background flushes, flushes the remaining users and returns Err.
+func (in *Ingestor) Close() error {
+	in.once.Do(func() {
+		in.mu.Lock()
+		in.closed = true
+		in.mu.Unlock()
+		close(in.stop)
+		<-in.done
+		in.Flush(context.Background())
+	})
+	return in.Err()
+}
@@ -0,0 +1,70 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestIngestor(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+
+	in, err := comfyent.NewIngestor(client, 16, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewIngestor: %v", err)
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 250; i++ {
+				u := comfyent.UserInput{Name: "u", Age: 30, Email: fmt.Sprintf("user%d-%d@example.com", w, i)}
+				if err := in.Add(u); err != nil {
+					t.Errorf("Add: %v", err)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if err := in.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if n := client.User.Query().CountX(context.Background()); n != 1000 {
+		t.Fatalf("got %d users after Close, want 1000", n)
+	}
+
+	err = in.Add(comfyent.UserInput{Name: "late", Age: 30, Email: "late@example.com"})
+	if !errors.Is(err, comfyent.ErrIngestorClosed) {
+		t.Fatalf("got error %v adding to a closed ingestor, want ErrIngestorClosed", err)
+	}
+}
+
+func TestIngestorSizeOnly(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+
+	if _, err := comfyent.NewIngestor(client, 10, -time.Second); err == nil {
+		t.Fatal("NewIngestor succeeded with a negative interval")
+	}
+	in, err := comfyent.NewIngestor(client, 10, 0)
+	if err != nil {
+		t.Fatalf("NewIngestor with a zero interval: %v", err)
+	}
+	for i := 0; i < 25; i++ {
+		in.Add(comfyent.UserInput{Name: "u", Age: 30, Email: fmt.Sprintf("user%d@example.com", i)})
+	}
+	if err := in.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if n := client.User.Query().CountX(context.Background()); n != 25 {
+		t.Fatalf("got %d users after Close, want 25", n)
+	}
+}
@@ -0,0 +1,47 @@
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// IntegrityCheck runs PRAGMA integrity_check and returns the problems it found, none meaning
+// the database is sound. It reads the whole file, so it takes a while on large databases.
+//
+// A corrupted database is best replaced by its latest backup. Otherwise, salvage what's left
+// with the sqlite3 shell into a new file, check it, then swap it in while the app is stopped:
+//
+//	sqlite3 ent.db .recover | sqlite3 recovered.db
+func IntegrityCheck(ctx context.Context, db *sql.DB) ([]string, error) {
+	return runCheck(ctx, db, "integrity_check")
+}
+
+// QuickCheck is a faster IntegrityCheck, running PRAGMA quick_check, which skips verifying
+// that indexes match their tables.
+func QuickCheck(ctx context.Context, db *sql.DB) ([]string, error) {
+	return runCheck(ctx, db, "quick_check")
+}
+
+func runCheck(ctx context.Context, db *sql.DB, pragma string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "PRAGMA "+pragma+";")
+	if err != nil {
+		return nil, fmt.Errorf("running %s: %w", pragma, err)
+	}
+	defer rows.Close()
+	var problems []string
+	for rows.Next() {
+		var msg string
+		if err := rows.Scan(&msg); err != nil {
+			return nil, fmt.Errorf("running %s: %w", pragma, err)
+		}
+		// A sound database yields a single "ok" row.
+		if msg != "ok" {
+			problems = append(problems, msg)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("running %s: %w", pragma, err)
+	}
+	return problems, nil
+}
@@ -0,0 +1,37 @@
+package comfyent_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestIntegrityCheck(t *testing.T) {
+	db := comfyent.OpenDB(newFileComfy(t))
+	defer db.Close()
+	ctx := context.Background()
+	for _, stmt := range []string{
+		"CREATE TABLE notes (id INTEGER PRIMARY KEY, body TEXT);",
+		"CREATE INDEX notes_body ON notes (body);",
+		"INSERT INTO notes (body) VALUES ('a'), ('b'), ('c');",
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, check := range map[string]func(context.Context, *sql.DB) ([]string, error){
+		"IntegrityCheck": comfyent.IntegrityCheck,
+		"QuickCheck":     comfyent.QuickCheck,
+	} {
+		problems, err := check(ctx, db)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(problems) != 0 {
+			t.Fatalf("%s found %q on a healthy database, want nothing", name, problems)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package comfyent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/predicate"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// iterateBatchSize is the number of users IterateUsers holds in memory at once.
+const iterateBatchSize = 500
+
+// ErrStopIteration can be returned by the callback of IterateUsers to stop early without error.
+var ErrStopIteration = errors.New("stop iteration")
+
+// IterateUsers calls fn for every user matching all the given predicates, in ID order.
+// Users are loaded iterateBatchSize at a time, seeking past the last ID seen, so memory
+// use doesn't depend on the number of users.
+//
+// Iteration stops at the first error returned by fn, which IterateUsers returns, except for
+// ErrStopIteration which makes it return nil.
+func IterateUsers(ctx context.Context, client *ent.Client, fn func(*ent.User) error, preds ...predicate.User) error {
+	for cursor := 0; ; {
+		batch, err := client.User.Query().
+			Where(preds...).
+			Where(user.IDGT(cursor)).
+			Order(ent.Asc(user.FieldID)).
+			Limit(iterateBatchSize).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("iterating users: %w", err)
+		}
+		for _, u := range batch {
+			if err := fn(u); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+		if len(batch) < iterateBatchSize {
+			return nil
+		}
+		cursor = batch[len(batch)-1].ID
+	}
+}
@@ -0,0 +1,71 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestIterateUsers(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	users := createManyUsers(t, client, 5000)
+	want := 0
+	for _, u := range users {
+		want += u.Age
+	}
+
+	sum, seen, last := 0, 0, 0
+	err := comfyent.IterateUsers(ctx, client, func(u *ent.User) error {
+		if u.ID <= last {
+			t.Fatalf("got user %d after %d, want ID order", u.ID, last)
+		}
+		sum, seen, last = sum+u.Age, seen+1, u.ID
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen != len(users) || sum != want {
+		t.Fatalf("iterated %d users summing to %d, want %d summing to %d", seen, sum, len(users), want)
+	}
+
+	// Predicates filter the users iterated over.
+	seen = 0
+	if err := comfyent.IterateUsers(ctx, client, func(*ent.User) error { seen++; return nil }, user.Age(20)); err != nil {
+		t.Fatal(err)
+	}
+	if seen != 100 {
+		t.Fatalf("iterated %d users aged 20, want 100", seen)
+	}
+}
+
+func TestIterateUsersStop(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	createManyUsers(t, client, 50)
+
+	seen := 0
+	err := comfyent.IterateUsers(ctx, client, func(*ent.User) error {
+		if seen++; seen == 10 {
+			return comfyent.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil || seen != 10 {
+		t.Fatalf("got %v after %d users, want nil after 10", err, seen)
+	}
+
+	errBoom := errors.New("boom")
+	err = comfyent.IterateUsers(ctx, client, func(*ent.User) error { return errBoom })
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("got %v, want the callback error", err)
+	}
+}
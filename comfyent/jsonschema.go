@@ -0,0 +1,49 @@
+package comfyent
+
+import (
+	"encoding/json"
+
+	entschema "github.com/davidroman0O/comfylite3-ent/ent/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// UserJSONSchema returns a JSON Schema (draft 2020-12) document describing users as ent
+// encodes them to JSON, for frontends to validate input and for API docs. The constraints
+// mirror the ones of the User schema; fields set by the database are marked readOnly.
+func UserJSONSchema() []byte {
+	readOnly := func(s map[string]any) map[string]any {
+		s["readOnly"] = true
+		return s
+	}
+	doc := map[string]any{
+		"$schema":  "https://json-schema.org/draft/2020-12/schema",
+		"title":    "User",
+		"type":     "object",
+		"required": []string{user.FieldName, user.FieldAge, user.FieldEmail},
+		"properties": map[string]any{
+			user.FieldID:        readOnly(map[string]any{"type": "integer"}),
+			user.FieldCreatedAt: readOnly(map[string]any{"type": "string", "format": "date-time"}),
+			user.FieldUpdatedAt: readOnly(map[string]any{"type": "string", "format": "date-time"}),
+			user.FieldDeletedAt: readOnly(map[string]any{"type": []string{"string", "null"}, "format": "date-time"}),
+			user.FieldName:      map[string]any{"type": "string", "minLength": 1},
+			user.FieldAge:       map[string]any{"type": "integer", "minimum": 1, "maximum": entschema.MaxAge},
+			user.FieldEmail:     map[string]any{"type": "string", "minLength": 1, "format": "email"},
+			user.FieldRole: map[string]any{
+				"type":    "string",
+				"enum":    []user.Role{user.RoleAdmin, user.RoleMember, user.RoleGuest},
+				"default": user.DefaultRole,
+			},
+			user.FieldVersion:  readOnly(map[string]any{"type": "integer"}),
+			user.FieldMetadata: map[string]any{"type": "object"},
+			user.FieldActive:   map[string]any{"type": "boolean", "default": true},
+			user.FieldTenantID: map[string]any{"type": "string"},
+			"edges":            readOnly(map[string]any{"type": "object"}),
+		},
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		// The document only holds strings, numbers and nested maps.
+		panic(err)
+	}
+	return data
+}
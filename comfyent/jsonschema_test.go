@@ -0,0 +1,63 @@
+package comfyent_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// compileUserSchema compiles UserJSONSchema, asserting formats such as email.
+func compileUserSchema(t *testing.T) *jsonschema.Schema {
+	t.Helper()
+	c := jsonschema.NewCompiler()
+	c.AssertFormat = true
+	if err := c.AddResource("user.json", bytes.NewReader(comfyent.UserJSONSchema())); err != nil {
+		t.Fatalf("loading the schema: %v", err)
+	}
+	schema, err := c.Compile("user.json")
+	if err != nil {
+		t.Fatalf("compiling the schema: %v", err)
+	}
+	return schema
+}
+
+func TestUserJSONSchema(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	schema := compileUserSchema(t)
+
+	// A user as ent encodes it is valid.
+	u := client.User.Create().SetName("alice").SetAge(30).SetEmail("alice@example.com").
+		SetMetadata(map[string]any{"plan": "pro"}).SaveX(context.Background())
+	data, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if err := schema.Validate(v); err != nil {
+		t.Fatalf("validating %s: %v", data, err)
+	}
+
+	for _, doc := range []string{
+		`{"name": "alice", "age": 30}`,
+		`{"name": "alice", "age": 0, "email": "alice@example.com"}`,
+		`{"name": "alice", "age": 30, "email": "not-an-email"}`,
+		`{"name": "alice", "age": 30, "email": "alice@example.com", "role": "owner"}`,
+	} {
+		var v any
+		if err := json.Unmarshal([]byte(doc), &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := schema.Validate(v); err == nil {
+			t.Errorf("%s is valid, want it rejected", doc)
+		}
+	}
+}
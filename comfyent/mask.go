@@ -0,0 +1,70 @@
+package comfyent
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// UserView is a user as shown in logs and debug output, where its email may be masked.
+type UserView struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Age       int       `json:"age"`
+	Email     string    `json:"email"`
+	Role      user.Role `json:"role"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MaskUser returns the view of u with the local part of its email masked, keeping its
+// first character and the domain: alice@example.com becomes a***@example.com.
+func MaskUser(u *ent.User) UserView {
+	v := newUserView(u)
+	v.Email = maskEmail(u.Email)
+	return v
+}
+
+type unmaskedKey struct{}
+
+// WithUnmasked returns a context for authorized callers, in which ViewUser leaves emails as is.
+func WithUnmasked(parent context.Context) context.Context {
+	return context.WithValue(parent, unmaskedKey{}, true)
+}
+
+// ViewUser returns the view of u, masked as by MaskUser unless ctx comes from WithUnmasked.
+func ViewUser(ctx context.Context, u *ent.User) UserView {
+	if unmasked, _ := ctx.Value(unmaskedKey{}).(bool); unmasked {
+		return newUserView(u)
+	}
+	return MaskUser(u)
+}
+
+func newUserView(u *ent.User) UserView {
+	return UserView{
+		ID:        u.ID,
+		Name:      u.Name,
+		Age:       u.Age,
+		Email:     u.Email,
+		Role:      u.Role,
+		Active:    u.Active,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+// maskEmail masks all of email but the first character of its local part and its domain.
+// Anything that doesn't look like an address is masked entirely.
+func maskEmail(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 1 {
+		return "***"
+	}
+	_, size := utf8.DecodeRuneInString(email)
+	return email[:size] + "***" + email[at:]
+}
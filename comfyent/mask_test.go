@@ -0,0 +1,38 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+func TestMaskUser(t *testing.T) {
+	for email, want := range map[string]string{
+		"alice@example.com": "a***@example.com",
+		"é@example.com":     "é***@example.com",
+		"@example.com":      "***",
+		"not-an-email":      "***",
+	} {
+		if got := comfyent.MaskUser(&ent.User{Email: email}).Email; got != want {
+			t.Errorf("got %q masking %q, want %q", got, email, want)
+		}
+	}
+}
+
+func TestViewUser(t *testing.T) {
+	u := &ent.User{ID: 1, Name: "alice", Email: "alice@example.com"}
+	ctx := context.Background()
+
+	if v := comfyent.ViewUser(ctx, u); v.Email != "a***@example.com" || v.Name != "alice" || v.ID != 1 {
+		t.Fatalf("got view %+v, want the email masked", v)
+	}
+	if v := comfyent.ViewUser(comfyent.WithUnmasked(ctx), u); v.Email != "alice@example.com" {
+		t.Fatalf("got email %q for an authorized caller, want it as is", v.Email)
+	}
+	// Viewing leaves the user alone.
+	if u.Email != "alice@example.com" {
+		t.Fatalf("got user email %q after viewing it, want it untouched", u.Email)
+	}
+}
@@ -0,0 +1,42 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// SetUserMeta sets key to val in the metadata of the user with the given ID. val can be
+// anything encoding/json can marshal, nested maps and slices included.
+//
+// The metadata is read, modified and written back as a whole: concurrent calls for the same
+// user may overwrite each other's keys, so run them within a transaction (see tx.WithTx)
+// with a client from ent.Tx.Client() when that matters.
+func SetUserMeta(ctx context.Context, client *ent.Client, id int, key string, val any) error {
+	u, err := client.User.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("loading user metadata: %w", err)
+	}
+	meta := make(map[string]any, len(u.Metadata)+1)
+	for k, v := range u.Metadata {
+		meta[k] = v
+	}
+	meta[key] = val
+	if err := client.User.UpdateOneID(id).SetMetadata(meta).Exec(ctx); err != nil {
+		return fmt.Errorf("saving user metadata: %w", err)
+	}
+	return nil
+}
+
+// GetUserMeta returns the value of key in the metadata of the user with the given ID, and
+// whether it's set. Values come back as decoded by encoding/json: numbers are float64,
+// objects map[string]any.
+func GetUserMeta(ctx context.Context, client *ent.Client, id int, key string) (any, bool, error) {
+	u, err := client.User.Get(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("loading user metadata: %w", err)
+	}
+	val, ok := u.Metadata[key]
+	return val, ok, nil
+}
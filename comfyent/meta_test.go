@@ -0,0 +1,48 @@
+package comfyent_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestUserMeta(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+
+	prefs := map[string]any{
+		"theme": "dark",
+		"notifications": map[string]any{
+			"email":    true,
+			"channels": []any{"news", "billing"},
+			"digest":   map[string]any{"hour": 8},
+		},
+	}
+	if err := comfyent.SetUserMeta(ctx, client, u.ID, "prefs", prefs); err != nil {
+		t.Fatal(err)
+	}
+	if err := comfyent.SetUserMeta(ctx, client, u.ID, "plan", "pro"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok, err := comfyent.GetUserMeta(ctx, client, u.ID, "prefs")
+	if err != nil || !ok {
+		t.Fatalf("got %v, %v reading prefs back, want them set", ok, err)
+	}
+	// Numbers come back as float64.
+	prefs["notifications"].(map[string]any)["digest"] = map[string]any{"hour": float64(8)}
+	if !reflect.DeepEqual(got, prefs) {
+		t.Fatalf("got prefs %v, want %v", got, prefs)
+	}
+	if plan, _, _ := comfyent.GetUserMeta(ctx, client, u.ID, "plan"); plan != "pro" {
+		t.Fatalf("got plan %v, want pro next to the prefs", plan)
+	}
+	if _, ok, err := comfyent.GetUserMeta(ctx, client, u.ID, "missing"); err != nil || ok {
+		t.Fatalf("got %v, %v for an unset key, want false", ok, err)
+	}
+}
@@ -0,0 +1,93 @@
+// Package metrics records Prometheus metrics for the statements ent sends to comfylite3.
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Driver is a dialect.Driver counting statements and errors and timing them, per operation.
+// The operation is the leading SQL keyword of the statement (select, insert, update, ...).
+type Driver struct {
+	*driver.Driver
+	queries  *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewDriver returns a driver recording metrics for the statements run by inner.
+// Register its Collector to expose them.
+func NewDriver(inner dialect.Driver) *Driver {
+	d := &Driver{
+		queries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "comfyent",
+			Name:      "queries_total",
+			Help:      "Number of statements executed.",
+		}, []string{"operation"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "comfyent",
+			Name:      "query_errors_total",
+			Help:      "Number of statements that returned an error.",
+		}, []string{"operation"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "comfyent",
+			Name:      "query_duration_seconds",
+			Help:      "Time spent executing statements.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+	}
+	d.Driver = driver.Wrap(inner, d.observe)
+	return d
+}
+
+// Collector returns the collector exposing the metrics of the driver.
+func (d *Driver) Collector() prometheus.Collector {
+	return collector{d}
+}
+
+func (d *Driver) observe(next driver.Handler) driver.Handler {
+	return func(ctx context.Context, stmt driver.Statement, v any) error {
+		op := operation(stmt.Query)
+		start := time.Now()
+		err := next(ctx, stmt, v)
+		d.duration.WithLabelValues(op).Observe(time.Since(start).Seconds())
+		d.queries.WithLabelValues(op).Inc()
+		if err != nil {
+			d.errors.WithLabelValues(op).Inc()
+		}
+		return err
+	}
+}
+
+// operation maps a statement to a bounded set of label values.
+func operation(query string) string {
+	switch kw := driver.Keyword(query); kw {
+	case "select", "insert", "update", "delete", "create", "alter", "drop", "pragma":
+		return kw
+	case "with":
+		return "select"
+	default:
+		return "other"
+	}
+}
+
+// collector gathers the vectors of a Driver into a single prometheus.Collector.
+type collector struct {
+	d *Driver
+}
+
+func (c collector) Describe(ch chan<- *prometheus.Desc) {
+	c.d.queries.Describe(ch)
+	c.d.errors.Describe(ch)
+	c.d.duration.Describe(ch)
+}
+
+func (c collector) Collect(ch chan<- prometheus.Metric) {
+	c.d.queries.Collect(ch)
+	c.d.errors.Collect(ch)
+	c.d.duration.Collect(ch)
+}
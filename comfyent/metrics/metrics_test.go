@@ -0,0 +1,72 @@
+package metrics_test
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/metrics"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	_ "github.com/davidroman0O/comfylite3-ent/ent/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// counter returns the value of the counter name for the statements of operation op.
+func counter(t *testing.T, reg *prometheus.Registry, name, op string) float64 {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "operation" && l.GetValue() == op {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestDriver(t *testing.T) {
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	drv := metrics.NewDriver(sql.OpenDB(dialect.SQLite, comfyent.OpenDB(comfy, comfylite3.WithForeignKeys())))
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(drv.Collector())
+	client := ent.NewClient(ent.Driver(drv))
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	before := counter(t, reg, "comfyent_queries_total", "select")
+	for i := 0; i < 3; i++ {
+		client.User.Query().CountX(ctx)
+	}
+	if got := counter(t, reg, "comfyent_queries_total", "select") - before; got != 3 {
+		t.Fatalf("counted %v selects, want 3", got)
+	}
+	if got := counter(t, reg, "comfyent_query_errors_total", "select"); got != 0 {
+		t.Fatalf("counted %v failed selects, want 0", got)
+	}
+
+	if _, err := client.QueryContext(ctx, "SELECT * FROM missing"); err == nil {
+		t.Fatal("queried a missing table")
+	}
+	if got := counter(t, reg, "comfyent_query_errors_total", "select"); got != 1 {
+		t.Fatalf("counted %v failed selects, want 1", got)
+	}
+}
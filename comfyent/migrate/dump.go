@@ -0,0 +1,35 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// DumpSchema writes the CREATE TABLE and CREATE INDEX statements of the ent schema to w, one
+// per line, as the SQLite migration engine generates them on a blank database. The schema is
+// compiled into the ent package, so unlike DiffPending no client is needed: the output
+// doesn't depend on the state of any database.
+func DumpSchema(ctx context.Context, w io.Writer) error {
+	db, err := sql.Open(dialect.SQLite, "file:dump?mode=memory&_fk=1")
+	if err != nil {
+		return fmt.Errorf("opening blank database: %w", err)
+	}
+	blank := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	defer blank.Close()
+	stmts, err := DiffPending(ctx, blank)
+	if err != nil {
+		return fmt.Errorf("dumping schema: %w", err)
+	}
+	for _, stmt := range stmts {
+		if _, err := fmt.Fprintf(w, "%s;\n", stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
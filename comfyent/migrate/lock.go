@@ -0,0 +1,111 @@
+package migrate
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrLockTimeout is returned by WithMigrationLock when another process kept the lock too long.
+var ErrLockTimeout = errors.New("timed out waiting for the migration lock")
+
+const (
+	// lockWait is how long WithMigrationLock waits for the lock, unless ctx ends sooner.
+	lockWait = time.Minute
+	// lockLease is how long the lock is held without being renewed, so a crashed process
+	// doesn't block migrations forever. It is renewed every lockLease/3 while migrating.
+	lockLease = 30 * time.Second
+	// lockPoll is the delay between two attempts to take the lock.
+	lockPoll = 100 * time.Millisecond
+)
+
+const lockDDL = `CREATE TABLE IF NOT EXISTS comfyent_locks (
+	name TEXT PRIMARY KEY,
+	owner TEXT NOT NULL,
+	expires_at INTEGER NOT NULL
+)`
+
+// WithMigrationLock runs fn, typically client.Schema.Create, while holding a lock stored in the
+// comfyent_locks table of db, so processes starting together migrate one after the other:
+//
+//	err := migrate.WithMigrationLock(ctx, db, func() error {
+//		return client.Schema.Create(ctx)
+//	})
+//
+// It waits up to a minute for the lock, then fails with ErrLockTimeout. The lock is a lease
+// renewed while fn runs: if the process dies, it is free again after 30 seconds.
+func WithMigrationLock(ctx context.Context, db *sql.DB, fn func() error) (err error) {
+	if _, err := db.ExecContext(ctx, lockDDL); err != nil {
+		return fmt.Errorf("creating lock table: %w", err)
+	}
+	owner, err := lockOwner()
+	if err != nil {
+		return err
+	}
+	if err := acquireLock(ctx, db, owner); err != nil {
+		return err
+	}
+	defer func() {
+		// Release even when ctx is done, for the next process not to wait for the lease to expire.
+		_, rerr := db.ExecContext(context.WithoutCancel(ctx), "DELETE FROM comfyent_locks WHERE name = 'migrate' AND owner = ?", owner)
+		if rerr != nil && err == nil {
+			err = fmt.Errorf("releasing migration lock: %w", rerr)
+		}
+	}()
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		ticker := time.NewTicker(lockLease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				db.ExecContext(ctx, "UPDATE comfyent_locks SET expires_at = ? WHERE name = 'migrate' AND owner = ?",
+					time.Now().Add(lockLease).UnixNano(), owner)
+			}
+		}
+	}()
+	return fn()
+}
+
+// acquireLock takes the lock for owner, waiting while another owner holds an unexpired lease.
+func acquireLock(ctx context.Context, db *sql.DB, owner string) error {
+	deadline := time.Now().Add(lockWait)
+	for {
+		now := time.Now()
+		// A single statement, so checking and taking the lock can't interleave with another process.
+		res, err := db.ExecContext(ctx, `INSERT INTO comfyent_locks (name, owner, expires_at) VALUES ('migrate', ?, ?)
+			ON CONFLICT (name) DO UPDATE SET owner = excluded.owner, expires_at = excluded.expires_at
+			WHERE comfyent_locks.expires_at < ?`, owner, now.Add(lockLease).UnixNano(), now.UnixNano())
+		if err != nil {
+			return fmt.Errorf("acquiring migration lock: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("acquiring migration lock: %w", err)
+		} else if n == 1 {
+			return nil
+		}
+		if now.After(deadline) {
+			return ErrLockTimeout
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("acquiring migration lock: %w", ctx.Err())
+		case <-time.After(lockPoll):
+		}
+	}
+}
+
+func lockOwner() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating lock owner: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
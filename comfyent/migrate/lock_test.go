@@ -0,0 +1,59 @@
+package migrate_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/migrate"
+)
+
+func TestWithMigrationLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	ctx := context.Background()
+
+	// Two handles on one file, like two processes starting together.
+	const migrators = 2
+	var (
+		wg       sync.WaitGroup
+		running  atomic.Int32
+		overlaps atomic.Int32
+		errs     = make([]error, migrators)
+	)
+	for i := range migrators {
+		client, comfy, err := comfyent.Open(comfyent.Config{Path: path, ForeignKeys: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer comfy.Close()
+		defer client.Close()
+		db := comfyent.OpenDB(comfy)
+		defer db.Close()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = migrate.WithMigrationLock(ctx, db, func() error {
+				if running.Add(1) > 1 {
+					overlaps.Add(1)
+				}
+				defer running.Add(-1)
+				time.Sleep(200 * time.Millisecond)
+				return client.Schema.Create(ctx)
+			})
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("migrator %d: %v", i, err)
+		}
+	}
+	if n := overlaps.Load(); n != 0 {
+		t.Fatal("both migrators held the lock at once")
+	}
+}
@@ -0,0 +1,66 @@
+// Package migrate inspects and manages schema migrations of ent clients running on comfylite3.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	atlas "ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqltool"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	entmigrate "github.com/davidroman0O/comfylite3-ent/ent/migrate"
+	_ "github.com/mattn/go-sqlite3" // driver of the dev database
+)
+
+// DiffPending returns the statements client.Schema.Create would run against the database,
+// without running them. An empty result means the database matches the ent schema,
+// which makes it usable in CI to catch unexpected schema changes before a deploy.
+func DiffPending(ctx context.Context, client *ent.Client, opts ...schema.MigrateOption) ([]string, error) {
+	var stmts []string
+	dryRun := schema.WithApplyHook(func(schema.Applier) schema.Applier {
+		return schema.ApplyFunc(func(_ context.Context, _ dialect.ExecQuerier, plan *atlas.Plan) error {
+			for _, c := range plan.Changes {
+				stmts = append(stmts, c.Cmd)
+			}
+			return nil
+		})
+	})
+	if err := client.Schema.Create(ctx, append(opts, dryRun)...); err != nil {
+		return nil, fmt.Errorf("computing pending migrations: %w", err)
+	}
+	return stmts, nil
+}
+
+// devURL is the scratch database migration files are replayed on to compute the next migration.
+const devURL = "sqlite://file?mode=memory&_fk=1"
+
+// GenerateMigration writes the migration bringing the schema recorded in dir up to date with the
+// ent schema, as a pair of timestamped <version>_<name>.up.sql and .down.sql files in the
+// golang-migrate format. The existing files of dir are the baseline: they are replayed on an
+// in-memory database and diffed against the ent schema. Nothing is written when they
+// already match it.
+//
+// dir is created if missing. Its atlas.sum file is updated alongside, so edited migrations
+// are detected on the next run.
+func GenerateMigration(dir, name string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating migration directory: %w", err)
+	}
+	d, err := sqltool.NewGolangMigrateDir(dir)
+	if err != nil {
+		return fmt.Errorf("opening migration directory: %w", err)
+	}
+	err = entmigrate.NamedDiff(context.Background(), devURL, name,
+		schema.WithDir(d),
+		schema.WithMigrationMode(schema.ModeReplay),
+		schema.WithDialect(dialect.SQLite),
+		schema.WithFormatter(sqltool.GolangMigrateFormatter),
+	)
+	if err != nil {
+		return fmt.Errorf("generating migration %q: %w", name, err)
+	}
+	return nil
+}
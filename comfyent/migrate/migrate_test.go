@@ -0,0 +1,85 @@
+package migrate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/migrate"
+)
+
+func TestDiffPending(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	stmts, err := migrate.DiffPending(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 0 {
+		t.Fatalf("got pending statements %q on a migrated database, want none", stmts)
+	}
+
+	if _, err := client.ExecContext(ctx, "ALTER TABLE users DROP COLUMN metadata;"); err != nil {
+		t.Fatal(err)
+	}
+	if stmts, err = migrate.DiffPending(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 1 || !strings.HasPrefix(stmts[0], "ALTER TABLE `users` ADD COLUMN `metadata`") {
+		t.Fatalf("got pending statements %q, want the metadata column added back", stmts)
+	}
+	// Nothing ran.
+	if _, err := client.ExecContext(ctx, "SELECT metadata FROM users;"); err == nil {
+		t.Fatal("the metadata column was added by DiffPending")
+	}
+}
+
+func TestGenerateMigration(t *testing.T) {
+	dir := t.TempDir()
+	if err := migrate.GenerateMigration(dir, "init"); err != nil {
+		t.Fatal(err)
+	}
+	ups, err := filepath.Glob(filepath.Join(dir, "*_init.up.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ups) != 1 {
+		t.Fatalf("got up migrations %q, want one", ups)
+	}
+	b, err := os.ReadFile(ups[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "CREATE TABLE `users`") {
+		t.Fatalf("got up migration %q, want the users table created", b)
+	}
+
+	// The directory is up to date, a second run writes nothing.
+	if err := migrate.GenerateMigration(dir, "noop"); err != nil {
+		t.Fatal(err)
+	}
+	if noops, _ := filepath.Glob(filepath.Join(dir, "*_noop.*")); len(noops) != 0 {
+		t.Fatalf("got %q, want no migration when the schema didn't change", noops)
+	}
+}
+
+func TestDumpSchema(t *testing.T) {
+	var buf strings.Builder
+	if err := migrate.DumpSchema(context.Background(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	dump := buf.String()
+	for _, want := range []string{
+		"CREATE TABLE `users` (",
+		"CREATE UNIQUE INDEX `users_email_key` ON `users` (`email`);\n",
+	} {
+		if !strings.Contains(dump, want) {
+			t.Fatalf("got dump %q, want it to contain %q", dump, want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/migrate"
+)
+
+// MigrateOptions configures Migrate. The zero value runs the same migration as a bare
+// client.Schema.Create.
+type MigrateOptions struct {
+	// DropColumn drops the columns no longer in the ent schema, see migrate.WithDropColumn.
+	DropColumn bool
+	// DropIndex drops the indexes no longer in the ent schema, see migrate.WithDropIndex.
+	DropIndex bool
+	// DisableForeignKeys creates tables without foreign key constraints, see migrate.WithForeignKeys.
+	DisableForeignKeys bool
+	// Options are appended to the ones above, e.g. WithUserSearch or WithCaseInsensitiveEmail.
+	Options []schema.MigrateOption
+}
+
+// Migrate brings the database of client up to date with the ent schema. Destructive changes
+// only happen when opts asks for them.
+func Migrate(ctx context.Context, client *ent.Client, opts MigrateOptions) error {
+	migrateOpts := []schema.MigrateOption{
+		migrate.WithDropColumn(opts.DropColumn),
+		migrate.WithDropIndex(opts.DropIndex),
+		migrate.WithForeignKeys(!opts.DisableForeignKeys),
+	}
+	if err := client.Schema.Create(ctx, append(migrateOpts, opts.Options...)...); err != nil {
+		return fmt.Errorf("migrating schema: %w", err)
+	}
+	return nil
+}
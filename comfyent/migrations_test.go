@@ -0,0 +1,55 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// hasColumn reports whether the users table has the column name.
+func hasColumn(t *testing.T, client *ent.Client, name string) bool {
+	t.Helper()
+	rows, err := client.QueryContext(context.Background(), "SELECT COUNT(*) FROM pragma_table_info('users') WHERE name = ?;", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var n int
+	if !rows.Next() {
+		t.Fatal("counting the columns of users: no row")
+	}
+	if err := rows.Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n > 0
+}
+
+func TestMigrateDropColumn(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	// A column left over from an older version of the schema.
+	if _, err := client.ExecContext(ctx, "ALTER TABLE `users` ADD COLUMN `nickname` TEXT;"); err != nil {
+		t.Fatal(err)
+	}
+	client.User.Create().SetName("alice").SetAge(30).SetEmail("alice@example.com").ExecX(ctx)
+
+	if err := comfyent.Migrate(ctx, client, comfyent.MigrateOptions{}); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if !hasColumn(t, client, "nickname") {
+		t.Fatal("Migrate dropped a column without DropColumn")
+	}
+	if err := comfyent.Migrate(ctx, client, comfyent.MigrateOptions{DropColumn: true}); err != nil {
+		t.Fatalf("Migrate with DropColumn: %v", err)
+	}
+	if hasColumn(t, client, "nickname") {
+		t.Fatal("got the nickname column after Migrate with DropColumn, want it dropped")
+	}
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users after dropping the column, want 1", n)
+	}
+}
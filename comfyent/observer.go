@@ -0,0 +1,35 @@
+package comfyent
+
+import (
+	"context"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/hook"
+)
+
+// UserCallback is notified of a user mutation once it succeeded.
+type UserCallback func(ctx context.Context, m *ent.UserMutation) error
+
+// RegisterUserHook calls fn after every successful user mutation of client, and of the
+// transactions it starts, whose operation is in op, e.g. ent.OpCreate or
+// ent.OpUpdate|ent.OpUpdateOne. Callbacks run in registration order.
+//
+// fn runs within the transaction of the mutation, if any: an error it returns fails the
+// mutation, so the caller can roll the change back along with the work done by fn.
+func RegisterUserHook(client *ent.Client, op ent.Op, fn UserCallback) {
+	client.User.Use(hook.On(func(next ent.Mutator) ent.Mutator {
+		return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+			op := m.Op()
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			// Soft deletes turn the mutation into an update, report it as the delete it was.
+			m.SetOp(op)
+			if err := fn(ctx, m); err != nil {
+				return nil, err
+			}
+			return v, nil
+		})
+	}, op))
+}
@@ -0,0 +1,46 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+func TestRegisterUserHook(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	var created []string
+	comfyent.RegisterUserHook(client, ent.OpCreate, func(ctx context.Context, m *ent.UserMutation) error {
+		email, _ := m.Email()
+		created = append(created, email)
+		return nil
+	})
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	client.User.UpdateOne(u).SetAge(31).ExecX(ctx)
+	if len(created) != 1 || created[0] != "a@example.com" {
+		t.Fatalf("got created emails %q, want a@example.com only", created)
+	}
+
+	// A failing callback fails the mutation, and the transaction can be rolled back.
+	errBoom := errors.New("boom")
+	comfyent.RegisterUserHook(client, ent.OpCreate, func(context.Context, *ent.UserMutation) error {
+		return errBoom
+	})
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.User.Create().SetName("b").SetAge(30).SetEmail("b@example.com").Exec(ctx); !errors.Is(err, errBoom) {
+		t.Fatalf("got %v, want the callback error", err)
+	}
+	tx.Rollback()
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users, want the failed one rolled back", n)
+	}
+}
@@ -0,0 +1,33 @@
+package comfyent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// ErrStaleVersion is returned by UpdateUserOptimistic when the user changed since it was read.
+var ErrStaleVersion = errors.New("stale user version")
+
+// UpdateUserOptimistic applies mut to the user with the given ID only if its version is still
+// expectedVersion, i.e. nobody updated it since the caller read it. Otherwise it fails with
+// ErrStaleVersion, and the caller should reload the user and retry.
+func UpdateUserOptimistic(ctx context.Context, client *ent.Client, id, expectedVersion int, mut func(*ent.UserUpdateOne)) (*ent.User, error) {
+	update := client.User.UpdateOneID(id).Where(user.Version(expectedVersion))
+	mut(update)
+	u, err := update.Save(ctx)
+	if err == nil {
+		return u, nil
+	}
+	if !ent.IsNotFound(err) {
+		return nil, fmt.Errorf("updating user %d: %w", id, err)
+	}
+	// Tell a missing user apart from an outdated version.
+	if exists, xerr := client.User.Query().Where(user.ID(id)).Exist(ctx); xerr != nil || !exists {
+		return nil, fmt.Errorf("updating user %d: %w", id, err)
+	}
+	return nil, fmt.Errorf("updating user %d from version %d: %w", id, expectedVersion, ErrStaleVersion)
+}
@@ -0,0 +1,39 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+func TestUpdateUserOptimistic(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+
+	// Two writers read the same version, the second one loses.
+	first, err := comfyent.UpdateUserOptimistic(ctx, client, u.ID, u.Version, func(m *ent.UserUpdateOne) { m.SetAge(31) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Version != u.Version+1 {
+		t.Fatalf("got version %d, want %d", first.Version, u.Version+1)
+	}
+	_, err = comfyent.UpdateUserOptimistic(ctx, client, u.ID, u.Version, func(m *ent.UserUpdateOne) { m.SetAge(32) })
+	if !errors.Is(err, comfyent.ErrStaleVersion) {
+		t.Fatalf("got %v, want ErrStaleVersion", err)
+	}
+	if got := client.User.GetX(ctx, u.ID); got.Age != 31 {
+		t.Fatalf("got age %d, want the first update only", got.Age)
+	}
+
+	_, err = comfyent.UpdateUserOptimistic(ctx, client, u.ID+1, 1, func(m *ent.UserUpdateOne) { m.SetAge(32) })
+	if !ent.IsNotFound(err) || errors.Is(err, comfyent.ErrStaleVersion) {
+		t.Fatalf("got %v for a missing user, want not found", err)
+	}
+}
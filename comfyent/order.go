@@ -0,0 +1,62 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// UserOrderField is a field users can be sorted by.
+type UserOrderField string
+
+// Fields users can be sorted by.
+const (
+	OrderByName      UserOrderField = user.FieldName
+	OrderByAge       UserOrderField = user.FieldAge
+	OrderByCreatedAt UserOrderField = user.FieldCreatedAt
+)
+
+var userOrders = map[UserOrderField]func(...sql.OrderTermOption) user.OrderOption{
+	OrderByName:      user.ByName,
+	OrderByAge:       user.ByAge,
+	OrderByCreatedAt: user.ByCreatedAt,
+}
+
+// OrderDir is a sort direction.
+type OrderDir string
+
+// Sort directions.
+const (
+	OrderAsc  OrderDir = "asc"
+	OrderDesc OrderDir = "desc"
+)
+
+// OrderedUsers returns every user sorted by the given field and direction, then by ID so
+// users sharing a value always come in the same order.
+//
+// by and dir are checked against the known values before reaching SQL, so they can come
+// straight from a request parameter.
+func OrderedUsers(ctx context.Context, client *ent.Client, by UserOrderField, dir OrderDir) ([]*ent.User, error) {
+	orderBy, ok := userOrders[by]
+	if !ok {
+		return nil, fmt.Errorf("cannot sort users by %q", by)
+	}
+	var opts []sql.OrderTermOption
+	switch dir {
+	case OrderAsc:
+	case OrderDesc:
+		opts = append(opts, sql.OrderDesc())
+	default:
+		return nil, fmt.Errorf("unknown sort direction %q", dir)
+	}
+	users, err := client.User.Query().
+		Order(orderBy(opts...), user.ByID(opts...)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying ordered users: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,41 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestOrderedUsers(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	// Ages 20 to 69 then again from 20: ties are broken by ID.
+	createManyUsers(t, client, 60)
+
+	users, err := comfyent.OrderedUsers(ctx, client, comfyent.OrderByAge, comfyent.OrderDesc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 60 {
+		t.Fatalf("got %d users, want 60", len(users))
+	}
+	for i := 1; i < len(users); i++ {
+		prev, u := users[i-1], users[i]
+		if u.Age > prev.Age || u.Age == prev.Age && u.ID > prev.ID {
+			t.Fatalf("got user %d aged %d after user %d aged %d, want age then ID descending", u.ID, u.Age, prev.ID, prev.Age)
+		}
+	}
+	if users[0].Age != 69 || users[len(users)-1].Age != 20 {
+		t.Fatalf("got ages %d to %d, want 69 to 20", users[0].Age, users[len(users)-1].Age)
+	}
+
+	if _, err := comfyent.OrderedUsers(ctx, client, "email; DROP TABLE users", comfyent.OrderAsc); err == nil {
+		t.Fatal("sorted by an unknown field")
+	}
+	if _, err := comfyent.OrderedUsers(ctx, client, comfyent.OrderByAge, "sideways"); err == nil {
+		t.Fatal("sorted in an unknown direction")
+	}
+}
@@ -0,0 +1,157 @@
+package comfyent
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/predicate"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// PageUsers returns up to limit users with an ID greater than afterID, ordered by ID,
+// along with the cursor to pass as afterID for the next page.
+// A zero cursor means there are no more pages. Unlike Offset, the cost of a page
+// doesn't grow with its position since SQLite seeks straight to afterID.
+func PageUsers(ctx context.Context, client *ent.Client, afterID int, limit int) ([]*ent.User, int, error) {
+	if limit < 1 {
+		return nil, 0, fmt.Errorf("page limit must be positive, got %d", limit)
+	}
+	// Fetch one extra user to know whether another page follows.
+	users, err := client.User.Query().
+		Where(user.IDGT(afterID)).
+		Order(ent.Asc(user.FieldID)).
+		Limit(limit + 1).
+		All(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("querying users page: %w", err)
+	}
+	if len(users) <= limit {
+		return users, 0, nil
+	}
+	users = users[:limit]
+	return users, users[limit-1].ID, nil
+}
+
+// ListOptions selects the page returned by ListUsers.
+type ListOptions struct {
+	// Page is the page number, starting at 1. Values below 1 are treated as 1.
+	Page     int
+	PageSize int
+	// Where filters the users listed and counted.
+	Where []predicate.User
+}
+
+// PageResult is a page of users along with what a UI needs to render pagination.
+type PageResult struct {
+	Items    []*ent.User
+	Total    int
+	Page     int
+	PageSize int
+	HasNext  bool
+}
+
+// ListUsers returns the page of users, ordered by ID, described by opts, and how many users
+// match overall. Unlike PageUsers it can jump to any page, but deep pages cost more as SQLite
+// walks past the users of all the previous ones.
+func ListUsers(ctx context.Context, client *ent.Client, opts ListOptions) (PageResult, error) {
+	if opts.PageSize < 1 {
+		return PageResult{}, fmt.Errorf("page size must be positive, got %d", opts.PageSize)
+	}
+	page := max(opts.Page, 1)
+	query := client.User.Query().Where(opts.Where...)
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return PageResult{}, fmt.Errorf("counting users: %w", err)
+	}
+	users, err := query.
+		Order(ent.Asc(user.FieldID)).
+		Offset((page - 1) * opts.PageSize).
+		Limit(opts.PageSize).
+		All(ctx)
+	if err != nil {
+		return PageResult{}, fmt.Errorf("querying users page: %w", err)
+	}
+	return PageResult{
+		Items:    users,
+		Total:    total,
+		Page:     page,
+		PageSize: opts.PageSize,
+		HasNext:  page*opts.PageSize < total,
+	}, nil
+}
+
+// Connection is a page of users following the Relay cursor connections specification.
+type Connection struct {
+	Edges      []Edge   `json:"edges"`
+	PageInfo   PageInfo `json:"pageInfo"`
+	TotalCount int      `json:"totalCount"`
+}
+
+// Edge is a user of a Connection along with its cursor.
+type Edge struct {
+	Node   *ent.User `json:"node"`
+	Cursor string    `json:"cursor"`
+}
+
+// PageInfo tells whether a Connection is followed by another page, and the cursor to get it.
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor,omitempty"`
+}
+
+// UserConnection returns the first users after the cursor after, or from the start if it is
+// empty, as a Relay connection. Cursors are opaque strings encoding user IDs, and pages are
+// fetched with PageUsers.
+func UserConnection(ctx context.Context, client *ent.Client, first int, after string) (*Connection, error) {
+	afterID := 0
+	if after != "" {
+		id, err := decodeCursor(after)
+		if err != nil {
+			return nil, err
+		}
+		afterID = id
+	}
+	users, next, err := PageUsers(ctx, client, afterID, first)
+	if err != nil {
+		return nil, err
+	}
+	total, err := client.User.Query().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("counting users: %w", err)
+	}
+	conn := &Connection{
+		Edges:      make([]Edge, len(users)),
+		PageInfo:   PageInfo{HasNextPage: next != 0},
+		TotalCount: total,
+	}
+	for i, u := range users {
+		conn.Edges[i] = Edge{Node: u, Cursor: encodeCursor(u.ID)}
+	}
+	if len(users) > 0 {
+		conn.PageInfo.EndCursor = conn.Edges[len(users)-1].Cursor
+	}
+	return conn, nil
+}
+
+// cursorPrefix keeps cursors from being mistaken for bare IDs.
+const cursorPrefix = "user:"
+
+func encodeCursor(id int) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(id)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	data, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor %q: %w", cursor, err)
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(string(data), cursorPrefix))
+	if err != nil || !strings.HasPrefix(string(data), cursorPrefix) {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return id, nil
+}
@@ -0,0 +1,151 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestPageUsers(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	users := createManyUsers(t, client, 50)
+
+	seen := make(map[int]int)
+	var prev, pages int
+	for cursor := 0; ; {
+		page, next, err := comfyent.PageUsers(ctx, client, cursor, 7)
+		if err != nil {
+			t.Fatalf("PageUsers(%d): %v", cursor, err)
+		}
+		pages++
+		for _, u := range page {
+			if u.ID <= prev {
+				t.Fatalf("got user %d after user %d, want ID order", u.ID, prev)
+			}
+			prev = u.ID
+			seen[u.ID]++
+		}
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+	if pages != 8 {
+		t.Fatalf("got %d pages, want 8", pages)
+	}
+	for _, u := range users {
+		if seen[u.ID] != 1 {
+			t.Fatalf("got user %d %d times, want once", u.ID, seen[u.ID])
+		}
+	}
+	if len(seen) != len(users) {
+		t.Fatalf("got %d users paged, want %d", len(seen), len(users))
+	}
+}
+
+func TestPageUsersExactPage(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	createManyUsers(t, client, 7)
+
+	page, next, err := comfyent.PageUsers(context.Background(), client, 0, 7)
+	if err != nil {
+		t.Fatalf("PageUsers: %v", err)
+	}
+	if len(page) != 7 || next != 0 {
+		t.Fatalf("got %d users and cursor %d, want 7 users and the end", len(page), next)
+	}
+}
+
+func TestPageUsersInvalidLimit(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+
+	if _, _, err := comfyent.PageUsers(context.Background(), client, 0, 0); err == nil {
+		t.Fatal("PageUsers succeeded with a zero limit")
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	users := createManyUsers(t, client, 23)
+
+	res, err := comfyent.ListUsers(ctx, client, comfyent.ListOptions{Page: 3, PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Items) != 3 || res.Total != 23 || res.Page != 3 || res.PageSize != 10 || res.HasNext {
+		t.Fatalf("got %d users, total %d, page %d of size %d, next %v, want the last 3 of 23 on page 3 of size 10",
+			len(res.Items), res.Total, res.Page, res.PageSize, res.HasNext)
+	}
+	for i, u := range res.Items {
+		if u.ID != users[20+i].ID {
+			t.Fatalf("got user %d at %d, want %d", u.ID, i, users[20+i].ID)
+		}
+	}
+
+	if res, err = comfyent.ListUsers(ctx, client, comfyent.ListOptions{Page: 0, PageSize: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if res.Page != 1 || len(res.Items) != 10 || !res.HasNext {
+		t.Fatalf("got page %d with %d users, next %v, want page 1 full and followed", res.Page, len(res.Items), res.HasNext)
+	}
+	if _, err := comfyent.ListUsers(ctx, client, comfyent.ListOptions{Page: 1}); err == nil {
+		t.Fatal("ListUsers succeeded with a zero page size")
+	}
+}
+
+func TestUserConnection(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	users := createManyUsers(t, client, 12)
+
+	var (
+		got   []int
+		after string
+		pages int
+	)
+	for {
+		conn, err := comfyent.UserConnection(ctx, client, 5, after)
+		if err != nil {
+			t.Fatalf("UserConnection(%q): %v", after, err)
+		}
+		pages++
+		if conn.TotalCount != 12 {
+			t.Fatalf("got total count %d, want 12", conn.TotalCount)
+		}
+		for _, e := range conn.Edges {
+			got = append(got, e.Node.ID)
+		}
+		if n := len(conn.Edges); n > 0 && conn.PageInfo.EndCursor != conn.Edges[n-1].Cursor {
+			t.Fatalf("got end cursor %q, want the cursor of the last edge %q", conn.PageInfo.EndCursor, conn.Edges[n-1].Cursor)
+		}
+		if !conn.PageInfo.HasNextPage {
+			break
+		}
+		after = conn.PageInfo.EndCursor
+	}
+	// 5, 5 then the last 2: no user is skipped or repeated across pages.
+	if pages != 3 {
+		t.Fatalf("got %d pages, want 3", pages)
+	}
+	if len(got) != len(users) {
+		t.Fatalf("got %d users paged, want %d", len(got), len(users))
+	}
+	for i, u := range users {
+		if got[i] != u.ID {
+			t.Fatalf("got user %d at %d, want %d", got[i], i, u.ID)
+		}
+	}
+
+	if _, err := comfyent.UserConnection(ctx, client, 5, "not-a-cursor"); err == nil {
+		t.Fatal("UserConnection succeeded with an invalid cursor")
+	}
+}
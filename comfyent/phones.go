@@ -0,0 +1,46 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/phone"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// UserPhoneCount is a user along with how many phones it owns.
+type UserPhoneCount struct {
+	User   *ent.User
+	Phones int
+}
+
+// UsersWithPhoneCount returns every user, ordered by ID, with the number of phones it owns.
+// Phones are counted by SQLite in the same query, they are never loaded.
+func UsersWithPhoneCount(ctx context.Context, client *ent.Client) ([]UserPhoneCount, error) {
+	q := client.User.Query().Order(ent.Asc(user.FieldID))
+	q.Modify(func(s *sql.Selector) {
+		t := sql.Table(phone.Table)
+		s.LeftJoin(t).On(s.C(user.FieldID), t.C(phone.OwnerColumn))
+		s.AppendSelectExprAs(sql.Expr(sql.Count(t.C(phone.FieldID))), "phone_count")
+		s.GroupBy(s.C(user.FieldID))
+	})
+	users, err := q.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("counting phones of users: %w", err)
+	}
+	counts := make([]UserPhoneCount, len(users))
+	for i, u := range users {
+		v, err := u.Value("phone_count")
+		if err != nil {
+			return nil, fmt.Errorf("reading phone count of user %d: %w", u.ID, err)
+		}
+		n, ok := v.(int64)
+		if !ok {
+			return nil, fmt.Errorf("reading phone count of user %d: got %T, want int64", u.ID, v)
+		}
+		counts[i] = UserPhoneCount{User: u, Phones: int(n)}
+	}
+	return counts, nil
+}
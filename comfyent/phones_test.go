@@ -0,0 +1,42 @@
+package comfyent_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/ent/schematype"
+)
+
+func TestUsersWithPhoneCount(t *testing.T) {
+	client, log := newRecordingClient(t)
+	ctx := context.Background()
+	users := createManyUsers(t, client, 3)
+	want := []int{0, 1, 3}
+	for i, u := range users {
+		for j := 0; j < want[i]; j++ {
+			client.Phone.Create().SetNumber(fmt.Sprintf("+3361234%d%d", i, j)).SetLabel(schematype.PhoneLabelMobile).SetOwner(u).ExecX(ctx)
+		}
+	}
+
+	n := log.Len()
+	counts, err := comfyent.UsersWithPhoneCount(ctx, client)
+	if err != nil {
+		t.Fatalf("UsersWithPhoneCount: %v", err)
+	}
+	if stmts := log.Since(n, "SELECT"); len(stmts) != 1 {
+		t.Fatalf("got SELECT statements %q, want one", stmts)
+	}
+	if len(counts) != len(want) {
+		t.Fatalf("got %d users, want %d", len(counts), len(want))
+	}
+	for i, c := range counts {
+		if c.User.ID != users[i].ID || c.Phones != want[i] {
+			t.Fatalf("got user %d with %d phones at %d, want user %d with %d", c.User.ID, c.Phones, i, users[i].ID, want[i])
+		}
+		if c.User.Edges.Phones != nil {
+			t.Fatalf("got the phones of user %d loaded, want them counted only", c.User.ID)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrHealthCheck is wrapped by the errors returned by Ping.
+var ErrHealthCheck = errors.New("comfylite3 health check failed")
+
+// Ping checks the database is reachable within timeout.
+// Failures wrap ErrHealthCheck along with the underlying error. It is safe to call repeatedly.
+func Ping(ctx context.Context, db *sql.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%w: %w", ErrHealthCheck, err)
+	}
+	return nil
+}
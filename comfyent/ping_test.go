@@ -0,0 +1,26 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestPing(t *testing.T) {
+	db := comfyent.OpenDB(newFileComfy(t))
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := comfyent.Ping(ctx, db, time.Second); err != nil {
+			t.Fatalf("Ping %d: %v", i+1, err)
+		}
+	}
+
+	db.Close()
+	err := comfyent.Ping(ctx, db, time.Second)
+	if !errors.Is(err, comfyent.ErrHealthCheck) {
+		t.Fatalf("got error %v pinging a closed database, want ErrHealthCheck", err)
+	}
+}
@@ -0,0 +1,80 @@
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// PoolConfig sizes the connection pool of a *sql.DB. Zero fields get a default suited to SQLite.
+type PoolConfig struct {
+	// MaxOpen caps the connections open at once, 1 by default. SQLite serializes writers on the
+	// file: more connections only add "database is locked" errors under rollback journaling.
+	// With WAL readers don't block the writer, and the default rises to one per CPU.
+	MaxOpen int
+	// MaxIdle caps the connections kept open between queries, MaxOpen by default. Reopening a
+	// connection is cheap with SQLite, but it loses the per-connection pragmas and page cache.
+	MaxIdle int
+	// ConnMaxLifetime closes connections once they have been open that long, never by default.
+	// There's no server to rebalance against, so recycling only costs reopening.
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime closes connections unused for that long, never by default. Worth setting
+	// with a large MaxIdle to release memory after bursts.
+	ConnMaxIdleTime time.Duration
+	// WAL tells the database runs in WAL mode, see OpenWithWAL.
+	WAL bool
+}
+
+// TunePool applies cfg to db's connection pool.
+func TunePool(db *sql.DB, cfg PoolConfig) {
+	if cfg.MaxOpen == 0 {
+		cfg.MaxOpen = 1
+		if cfg.WAL {
+			cfg.MaxOpen = runtime.NumCPU()
+		}
+	}
+	if cfg.MaxIdle == 0 {
+		cfg.MaxIdle = cfg.MaxOpen
+	}
+	db.SetMaxOpenConns(cfg.MaxOpen)
+	db.SetMaxIdleConns(cfg.MaxIdle)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// Warmup opens and pings n connections of db concurrently, so the first queries served don't
+// pay for opening them and applying their pragmas. The connections are all held until the
+// last one is ready, then returned to the pool: keeping them needs MaxIdle to be at least n,
+// and MaxOpen caps how many can be opened at all.
+func Warmup(ctx context.Context, db *sql.DB, n int) error {
+	conns := make([]*sql.Conn, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := db.Conn(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			conns[i] = conn
+			errs[i] = conn.PingContext(ctx)
+		}()
+	}
+	wg.Wait()
+	for _, conn := range conns {
+		if conn != nil {
+			conn.Close()
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("warming up connections: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package comfyent_test
+
+import (
+	"context"
+	"database/sql"
+	"runtime"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestTunePool(t *testing.T) {
+	db := comfyent.OpenDB(newFileComfy(t))
+	defer db.Close()
+	ctx := context.Background()
+
+	comfyent.TunePool(db, comfyent.PoolConfig{MaxOpen: 3, MaxIdle: 2})
+	if got := db.Stats().MaxOpenConnections; got != 3 {
+		t.Fatalf("got MaxOpenConnections %d, want 3", got)
+	}
+	// Open 3 connections at once, only 2 stay idle once released.
+	conns := make([]*sql.Conn, 3)
+	for i := range conns {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conns[i] = conn
+	}
+	for _, conn := range conns {
+		conn.Close()
+	}
+	if stats := db.Stats(); stats.Idle != 2 || stats.MaxIdleClosed == 0 {
+		t.Fatalf("got %d idle connections and %d closed for MaxIdle, want 2 and some", stats.Idle, stats.MaxIdleClosed)
+	}
+
+	comfyent.TunePool(db, comfyent.PoolConfig{})
+	if got := db.Stats().MaxOpenConnections; got != 1 {
+		t.Fatalf("got MaxOpenConnections %d by default, want 1", got)
+	}
+	comfyent.TunePool(db, comfyent.PoolConfig{WAL: true})
+	if got := db.Stats().MaxOpenConnections; got != runtime.NumCPU() {
+		t.Fatalf("got MaxOpenConnections %d in WAL mode, want %d", got, runtime.NumCPU())
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	db := comfyent.OpenDB(newFileComfy(t))
+	defer db.Close()
+	comfyent.TunePool(db, comfyent.PoolConfig{MaxOpen: 4, MaxIdle: 4})
+
+	if err := comfyent.Warmup(context.Background(), db, 4); err != nil {
+		t.Fatalf("Warmup: %v", err)
+	}
+	if stats := db.Stats(); stats.OpenConnections != 4 || stats.Idle != 4 {
+		t.Fatalf("got %d open connections, %d idle, want 4 ready to serve", stats.OpenConnections, stats.Idle)
+	}
+}
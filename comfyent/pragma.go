@@ -0,0 +1,66 @@
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Pragma configures a database right after it was opened.
+//
+// comfylite3 runs every statement on a single underlying connection, so a pragma applied
+// once holds for every query going through the *sql.DB returned by OpenDB.
+type Pragma func(ctx context.Context, db *sql.DB) error
+
+// ApplyPragmas runs the given pragmas against db, stopping at the first failure.
+func ApplyPragmas(ctx context.Context, db *sql.DB, pragmas ...Pragma) error {
+	for _, pragma := range pragmas {
+		if err := pragma(ctx, db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithBusyTimeout makes SQLite wait up to d for a lock to be released
+// instead of failing right away with "database is locked".
+func WithBusyTimeout(d time.Duration) Pragma {
+	return func(ctx context.Context, db *sql.DB) error {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("PRAGMA busy_timeout = %d;", d.Milliseconds())); err != nil {
+			return fmt.Errorf("setting busy_timeout: %w", err)
+		}
+		return nil
+	}
+}
+
+// ErrEncryptionUnsupported is returned by WithEncryptionKey when SQLite wasn't built with SQLCipher.
+var ErrEncryptionUnsupported = errors.New("encryption not supported by this driver build")
+
+// WithEncryptionKey unlocks a SQLCipher encrypted database with key, and checks the key is
+// right by reading sqlite_master. It must be the first statement run on the database.
+//
+// The stock mattn/go-sqlite3 build has no SQLCipher support: the pragma fails with
+// ErrEncryptionUnsupported instead of leaving the data in plaintext.
+func WithEncryptionKey(key string) Pragma {
+	return func(ctx context.Context, db *sql.DB) error {
+		// cipher_version only returns a row on SQLCipher builds, plain SQLite ignores unknown pragmas.
+		var version string
+		switch err := db.QueryRowContext(ctx, "PRAGMA cipher_version;").Scan(&version); {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrEncryptionUnsupported
+		case err != nil:
+			return fmt.Errorf("checking SQLCipher support: %w", err)
+		}
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("PRAGMA key = '%s';", strings.ReplaceAll(key, "'", "''"))); err != nil {
+			return fmt.Errorf("setting encryption key: %w", err)
+		}
+		var n int
+		if err := db.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master;").Scan(&n); err != nil {
+			return fmt.Errorf("verifying encryption key: %w", err)
+		}
+		return nil
+	}
+}
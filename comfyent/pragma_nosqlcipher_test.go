@@ -0,0 +1,22 @@
+//go:build !sqlcipher
+
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestWithEncryptionKeyUnsupported(t *testing.T) {
+	db := comfyent.OpenDB(newFileComfy(t))
+	defer db.Close()
+	ctx := context.Background()
+
+	err := comfyent.ApplyPragmas(ctx, db, comfyent.WithEncryptionKey("key"))
+	if !errors.Is(err, comfyent.ErrEncryptionUnsupported) {
+		t.Fatalf("got %v, want ErrEncryptionUnsupported", err)
+	}
+}
@@ -0,0 +1,43 @@
+//go:build sqlcipher
+
+package comfyent_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+// The sqlcipher tag is for SQLite builds linked against SQLCipher, which the stock
+// mattn/go-sqlite3 build isn't.
+func TestWithEncryptionKeyWrongKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret.db")
+	ctx := context.Background()
+	open := func(key string) error {
+		comfy, err := comfylite3.New(comfylite3.WithPath(path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer comfy.Close()
+		db := comfyent.OpenDB(comfy)
+		defer db.Close()
+		if err := comfyent.ApplyPragmas(ctx, db, comfyent.WithEncryptionKey(key)); err != nil {
+			return err
+		}
+		_, err = db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS secrets (s TEXT);")
+		return err
+	}
+
+	if err := open("right"); err != nil {
+		t.Fatalf("creating the database: %v", err)
+	}
+	if err := open("wrong"); err == nil {
+		t.Fatal("the database opened with the wrong key")
+	}
+	if err := open("right"); err != nil {
+		t.Fatalf("reopening with the right key: %v", err)
+	}
+}
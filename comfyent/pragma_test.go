@@ -0,0 +1,63 @@
+package comfyent_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestWithBusyTimeout(t *testing.T) {
+	db := comfyent.OpenDB(newFileComfy(t))
+	defer db.Close()
+	ctx := context.Background()
+
+	if err := comfyent.ApplyPragmas(ctx, db, comfyent.WithBusyTimeout(2*time.Second)); err != nil {
+		t.Fatalf("ApplyPragmas: %v", err)
+	}
+	var timeout int
+	if err := db.QueryRowContext(ctx, "PRAGMA busy_timeout;").Scan(&timeout); err != nil {
+		t.Fatal(err)
+	}
+	if timeout != 2000 {
+		t.Fatalf("got busy_timeout %d, want 2000", timeout)
+	}
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE counters (n INTEGER);"); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = func() error {
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					return err
+				}
+				defer tx.Rollback()
+				if _, err := tx.ExecContext(ctx, "INSERT INTO counters (n) VALUES (?);", i); err != nil {
+					return err
+				}
+				return tx.Commit()
+			}()
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("transaction %d: %v", i, err)
+		}
+	}
+	var n int
+	if err := db.QueryRowContext(ctx, "SELECT count(*) FROM counters;").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("got %d rows, want 2", n)
+	}
+}
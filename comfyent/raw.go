@@ -0,0 +1,54 @@
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+)
+
+// QueryRows runs a raw SELECT on db, for reports ent can't express. Anything else is refused
+// before reaching SQLite, as are several statements at once: semicolons may only end the
+// query, pass values containing one as arguments.
+func QueryRows(ctx context.Context, db *sql.DB, query string, args ...any) (*sql.Rows, error) {
+	if kw := driver.Keyword(query); kw != "select" {
+		return nil, fmt.Errorf("raw query must be a SELECT, got %q", kw)
+	}
+	// mattn/go-sqlite3 runs every statement of a query, only returning the rows of the last one.
+	if strings.Contains(strings.TrimRight(query, "; \t\r\n"), ";") {
+		return nil, fmt.Errorf("raw query must be a single statement")
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("running raw query: %w", err)
+	}
+	return rows, nil
+}
+
+// QueryInto runs query like QueryRows and returns its rows, each turned into a T by scan:
+//
+//	counts, err := comfyent.QueryInto(ctx, db, func(rows *sql.Rows) (RoleCount, error) {
+//		var c RoleCount
+//		return c, rows.Scan(&c.Role, &c.Count)
+//	}, "SELECT role, count(*) FROM users GROUP BY role")
+func QueryInto[T any](ctx context.Context, db *sql.DB, scan func(*sql.Rows) (T, error), query string, args ...any) ([]T, error) {
+	rows, err := QueryRows(ctx, db, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []T
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning raw query row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("running raw query: %w", err)
+	}
+	return items, nil
+}
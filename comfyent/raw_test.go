@@ -0,0 +1,57 @@
+package comfyent_test
+
+import (
+	"context"
+	stdsql "database/sql"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+type roleCount struct {
+	Role  string
+	Count int
+}
+
+func TestQueryInto(t *testing.T) {
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	db := comfyent.OpenDB(comfy, comfylite3.WithForeignKeys())
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.SQLite, db)))
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	users := createManyUsers(t, client, 5)
+	client.User.Update().Where(user.IDIn(users[0].ID, users[1].ID)).SetRole(user.RoleAdmin).ExecX(ctx)
+
+	counts, err := comfyent.QueryInto(ctx, db, func(rows *stdsql.Rows) (roleCount, error) {
+		var c roleCount
+		return c, rows.Scan(&c.Role, &c.Count)
+	}, "SELECT role, count(*) FROM users WHERE age >= ? GROUP BY role ORDER BY role", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []roleCount{{"admin", 2}, {"member", 3}}
+	if len(counts) != len(want) || counts[0] != want[0] || counts[1] != want[1] {
+		t.Fatalf("got %v, want %v", counts, want)
+	}
+
+	// Scan errors are reported.
+	_, err = comfyent.QueryInto(ctx, db, func(rows *stdsql.Rows) (roleCount, error) {
+		var c roleCount
+		return c, rows.Scan(&c.Role)
+	}, "SELECT role, count(*) FROM users GROUP BY role")
+	if err == nil {
+		t.Fatal("scanning 2 columns into 1 succeeded")
+	}
+}
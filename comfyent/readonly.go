@@ -0,0 +1,40 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// OpenReadOnly returns a client on comfy whose writes fail with driver.ErrReadOnly
+// while queries keep working, for components such as reporting that must never write.
+//
+// The check is done by the client, the file itself is opened by comfylite3: to also have
+// SQLite refuse writes, create comfy on a read-only DSN, e.g.
+// comfylite3.WithConnection("file:ent.db?mode=ro"), or use OpenReadOnlyConfig.
+func OpenReadOnly(comfy *comfylite3.ComfyDB, opts ...comfylite3.OpenDBOption) *ent.Client {
+	drv := driver.NewReadOnlyDriver(sql.OpenDB(dialect.SQLite, OpenDB(comfy, opts...)))
+	return ent.NewClient(ent.Driver(drv))
+}
+
+// OpenReadOnlyConfig is like Open, with the client of OpenReadOnly: SQLite refuses writes too,
+// as the file is opened with mode=ro and the connection has PRAGMA query_only set. cfg.Mode is
+// ignored.
+func OpenReadOnlyConfig(cfg Config) (*ent.Client, *comfylite3.ComfyDB, error) {
+	cfg.Mode = "ro"
+	drv, comfy, err := openDriver(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	// In-memory databases have no mode=ro, query_only covers them alike.
+	if _, err := comfy.ExecContext(context.Background(), "PRAGMA query_only = ON;"); err != nil {
+		comfy.Close()
+		return nil, nil, fmt.Errorf("making connection read-only: %w", err)
+	}
+	return ent.NewClient(ent.Driver(driver.NewReadOnlyDriver(drv))), comfy, nil
+}
@@ -0,0 +1,78 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// seedFile creates a database at a temporary path holding a single user, and returns the path.
+func seedFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	client, comfy, err := comfyent.Open(comfyent.Config{Path: path, ForeignKeys: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").ExecX(ctx)
+	return path
+}
+
+// checkReadOnly checks client reads, and refuses writes with driver.ErrReadOnly.
+func checkReadOnly(t *testing.T, client *ent.Client) {
+	t.Helper()
+	ctx := context.Background()
+	if n, err := client.User.Query().Count(ctx); err != nil || n != 1 {
+		t.Fatalf("got %d users and error %v, want 1 user", n, err)
+	}
+	_, err := client.User.Create().SetName("b").SetAge(30).SetEmail("b@example.com").Save(ctx)
+	if !errors.Is(err, driver.ErrReadOnly) {
+		t.Fatalf("got error %v creating a user, want ErrReadOnly", err)
+	}
+	if _, err := client.ExecContext(ctx, "PRAGMA query_only = OFF;"); !errors.Is(err, driver.ErrReadOnly) {
+		t.Fatalf("got error %v setting a pragma, want ErrReadOnly", err)
+	}
+	rows, err := client.QueryContext(ctx, "PRAGMA table_info(users);")
+	if err != nil {
+		t.Fatalf("reading a pragma: %v", err)
+	}
+	rows.Close()
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	comfy, err := comfylite3.New(comfylite3.WithPath(seedFile(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	client := comfyent.OpenReadOnly(comfy)
+	defer client.Close()
+	checkReadOnly(t, client)
+}
+
+func TestOpenReadOnlyConfig(t *testing.T) {
+	client, comfy, err := comfyent.OpenReadOnlyConfig(comfyent.Config{Path: seedFile(t)})
+	if err != nil {
+		t.Fatalf("OpenReadOnlyConfig: %v", err)
+	}
+	defer comfy.Close()
+	defer client.Close()
+	checkReadOnly(t, client)
+
+	// SQLite refuses the writes the client would let through.
+	if _, err := comfy.ExecContext(context.Background(), "DELETE FROM users;"); err == nil {
+		t.Fatal("deleted users on a read-only connection")
+	}
+}
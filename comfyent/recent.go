@@ -0,0 +1,24 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// RecentUsers returns the users created within the last since, newest first.
+func RecentUsers(ctx context.Context, client *ent.Client, since time.Duration) ([]*ent.User, error) {
+	users, err := client.User.Query().
+		Where(user.CreatedAtGTE(time.Now().Add(-since))).
+		Order(user.ByCreatedAt(sql.OrderDesc()), user.ByID(sql.OrderDesc())).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying recent users: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,37 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestRecentUsers(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	now := time.Now()
+	for name, age := range map[string]time.Duration{
+		"minutes": 10 * time.Minute,
+		"hours":   5 * time.Hour,
+		"days":    3 * 24 * time.Hour,
+		"seconds": 10 * time.Second,
+	} {
+		client.User.Create().SetName(name).SetAge(30).SetEmail(name + "@example.com").SetCreatedAt(now.Add(-age)).ExecX(ctx)
+	}
+
+	users, err := comfyent.RecentUsers(ctx, client, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, u := range users {
+		names = append(names, u.Name)
+	}
+	if len(names) != 3 || names[0] != "seconds" || names[1] != "minutes" || names[2] != "hours" {
+		t.Fatalf("got %q, want the users of the last day, newest first", names)
+	}
+}
@@ -0,0 +1,73 @@
+package comfyent
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// Registry holds the clients of the databases an application uses, e.g. a main one and an
+// archive, by name, and closes them all together. It is safe for concurrent use.
+type Registry struct {
+	mu  sync.RWMutex
+	dbs map[string]registered
+}
+
+type registered struct {
+	client *ent.Client
+	comfy  *comfylite3.ComfyDB
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{dbs: make(map[string]registered)}
+}
+
+// Register opens a client on comfy with OpenDB and records it under name. The registry takes
+// ownership of comfy, which is closed by CloseAll.
+//
+// Databases are only as separate as their comfylite3 handles: comfylite3.WithMemory always
+// opens the same shared in-memory database, give each one its own name with WithConnection,
+// e.g. "file:archive?mode=memory&cache=shared".
+func (r *Registry) Register(name string, comfy *comfylite3.ComfyDB, opts ...comfylite3.OpenDBOption) (*ent.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.dbs[name]; ok {
+		return nil, fmt.Errorf("database %q is already registered", name)
+	}
+	client := ent.NewClient(ent.Driver(driver.NewImplicitTxDriver(sql.OpenDB(dialect.SQLite, OpenDB(comfy, opts...)))))
+	r.dbs[name] = registered{client: client, comfy: comfy}
+	return client, nil
+}
+
+// Client returns the client registered under name.
+func (r *Registry) Client(name string) (*ent.Client, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	db, ok := r.dbs[name]
+	return db.client, ok
+}
+
+// CloseAll closes every registered client and its comfylite3 handle, and empties the registry.
+// It returns the errors of all the databases that failed to close.
+func (r *Registry) CloseAll() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var errs []error
+	for name, db := range r.dbs {
+		if err := db.client.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing client of %q: %w", name, err))
+		}
+		if err := db.comfy.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing database %q: %w", name, err))
+		}
+		delete(r.dbs, name)
+	}
+	return errors.Join(errs...)
+}
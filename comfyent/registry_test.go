@@ -0,0 +1,54 @@
+package comfyent_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestRegistry(t *testing.T) {
+	reg := comfyent.NewRegistry()
+	ctx := context.Background()
+	for _, name := range []string{"main", "archive"} {
+		comfy, err := comfylite3.New(
+			comfylite3.WithMemory(),
+			comfylite3.WithConnection(fmt.Sprintf("file:%s_%s?mode=memory&cache=shared", t.Name(), name)),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client, err := reg.Register(name, comfy, comfylite3.WithForeignKeys())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.Schema.Create(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := reg.Register("main", nil); err == nil {
+		t.Fatal("registered main twice")
+	}
+
+	main, _ := reg.Client("main")
+	archive, ok := reg.Client("archive")
+	if !ok {
+		t.Fatal("archive is not registered")
+	}
+	main.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").ExecX(ctx)
+	if n := archive.User.Query().CountX(ctx); n != 0 {
+		t.Fatalf("got %d users in the archive, want the main database's user kept apart", n)
+	}
+	if n := main.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users in main, want 1", n)
+	}
+
+	if err := reg.CloseAll(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reg.Client("main"); ok {
+		t.Fatal("main is still registered after CloseAll")
+	}
+}
@@ -0,0 +1,105 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/ent/migrate"
+)
+
+// Restore replaces the content of every ent table with the rows found in the backup at srcPath.
+//
+// The backup is attached read-only and each table is checked against the current ent schema
+// first: the restore is refused if a column is missing. Rows are then copied within a single
+// transaction, so either everything is restored or the database is left untouched.
+// It relies on comfylite3 running every statement on its single underlying connection,
+// which is what makes the attached database visible to the transaction.
+func Restore(ctx context.Context, comfy *comfylite3.ComfyDB, srcPath string) (err error) {
+	if _, err := os.Stat(srcPath); err != nil {
+		return fmt.Errorf("opening backup: %w", err)
+	}
+	if _, err := comfy.ExecContext(ctx, "ATTACH DATABASE ? AS restore_src;", "file:"+srcPath+"?mode=ro"); err != nil {
+		return fmt.Errorf("attaching backup: %w", err)
+	}
+	defer comfy.ExecContext(context.Background(), "DETACH DATABASE restore_src;")
+
+	for _, t := range migrate.Tables {
+		if err := checkColumns(ctx, comfy, t.Name, t.Columns); err != nil {
+			return err
+		}
+	}
+
+	tx, err := comfy.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting restore transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	// Tables are refilled one at a time, so foreign keys are only checked on commit.
+	if _, err := tx.ExecContext(ctx, "PRAGMA defer_foreign_keys = ON;"); err != nil {
+		return fmt.Errorf("deferring foreign keys: %w", err)
+	}
+	for _, t := range migrate.Tables {
+		cols := make([]string, len(t.Columns))
+		for i, c := range t.Columns {
+			cols[i] = quoteIdent(c.Name)
+		}
+		list := strings.Join(cols, ", ")
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM main.%s;", quoteIdent(t.Name))); err != nil {
+			return fmt.Errorf("clearing table %s: %w", t.Name, err)
+		}
+		query := fmt.Sprintf("INSERT INTO main.%[1]s (%[2]s) SELECT %[2]s FROM restore_src.%[1]s;", quoteIdent(t.Name), list)
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("restoring table %s: %w", t.Name, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing restore: %w", err)
+	}
+	return nil
+}
+
+// checkColumns verifies the backup holds the table with all the given columns.
+func checkColumns(ctx context.Context, comfy *comfylite3.ComfyDB, table string, columns []*schema.Column) error {
+	rows, err := comfy.QueryContext(ctx, "SELECT name FROM pragma_table_info(?, 'restore_src');", table)
+	if err != nil {
+		return fmt.Errorf("reading backup columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+	found := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return fmt.Errorf("reading backup columns of %s: %w", table, err)
+		}
+		found[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("reading backup columns of %s: %w", table, err)
+	}
+	if len(found) == 0 {
+		return fmt.Errorf("backup has no table %s", table)
+	}
+	var missing []string
+	for _, c := range columns {
+		if !found[c.Name] {
+			missing = append(missing, c.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("backup table %s is missing columns: %s", table, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// quoteIdent quotes a SQLite identifier.
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,58 @@
+package comfyent_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/schema"
+)
+
+func TestRestore(t *testing.T) {
+	client, comfy := newFileClient(t)
+	ctx := context.Background()
+	createUsers(t, client, "a@example.com", "b@example.com")
+
+	backup := filepath.Join(t.TempDir(), "backup.db")
+	if err := comfyent.Backup(ctx, comfy, backup); err != nil {
+		t.Fatal(err)
+	}
+	client.User.Delete().ExecX(schema.SkipSoftDelete(ctx))
+	createUsers(t, client, "c@example.com")
+
+	if err := comfyent.Restore(ctx, comfy, backup); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	emails := client.User.Query().Order(ent.Asc("email")).Select("email").StringsX(ctx)
+	if len(emails) != 2 || emails[0] != "a@example.com" || emails[1] != "b@example.com" {
+		t.Fatalf("got users %v after the restore, want a@example.com and b@example.com", emails)
+	}
+}
+
+func TestRestoreRejectsIncompleteBackup(t *testing.T) {
+	client, comfy := newFileClient(t)
+	ctx := context.Background()
+	createUsers(t, client, "a@example.com")
+
+	// A backup from an older schema, whose users table lacks most columns.
+	old := newFileComfy(t)
+	if _, err := old.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT);"); err != nil {
+		t.Fatal(err)
+	}
+	backup := filepath.Join(t.TempDir(), "old.db")
+	if err := comfyent.Backup(ctx, old, backup); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := comfyent.Restore(ctx, comfy, backup); err == nil {
+		t.Fatal("Restore accepted a backup missing columns")
+	}
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users after a refused restore, want the database untouched", n)
+	}
+	if err := comfyent.Restore(ctx, comfy, filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Fatal("Restore accepted a missing backup")
+	}
+}
@@ -0,0 +1,17 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// PromoteToAdmin gives the admin role to the user with the given ID.
+func PromoteToAdmin(ctx context.Context, client *ent.Client, id int) error {
+	if err := client.User.UpdateOneID(id).SetRole(user.RoleAdmin).Exec(ctx); err != nil {
+		return fmt.Errorf("promoting user %d to admin: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestPromoteToAdmin(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	if u.Role != user.RoleMember {
+		t.Fatalf("got role %s for a new user, want member", u.Role)
+	}
+
+	if err := comfyent.PromoteToAdmin(ctx, client, u.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := client.User.GetX(ctx, u.ID); got.Role != user.RoleAdmin {
+		t.Fatalf("got role %s, want admin", got.Role)
+	}
+	if err := comfyent.PromoteToAdmin(ctx, client, u.ID+1); !ent.IsNotFound(err) {
+		t.Fatalf("got %v for a missing user, want not found", err)
+	}
+}
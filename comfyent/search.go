@@ -0,0 +1,175 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	atlas "ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/predicate"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// searchTable is the FTS5 index over user names and emails.
+const searchTable = "users_fts"
+
+// searchDDL creates the index and the triggers keeping it in sync with the users table.
+var searchDDL = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS users_fts USING fts5(name, email, content='users', content_rowid='id')`,
+	`CREATE TRIGGER IF NOT EXISTS users_fts_ai AFTER INSERT ON users BEGIN
+		INSERT INTO users_fts(rowid, name, email) VALUES (new.id, new.name, new.email);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS users_fts_ad AFTER DELETE ON users BEGIN
+		INSERT INTO users_fts(users_fts, rowid, name, email) VALUES ('delete', old.id, old.name, old.email);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS users_fts_au AFTER UPDATE ON users BEGIN
+		INSERT INTO users_fts(users_fts, rowid, name, email) VALUES ('delete', old.id, old.name, old.email);
+		INSERT INTO users_fts(rowid, name, email) VALUES (new.id, new.name, new.email);
+	END`,
+}
+
+// WithUserSearch is a migration option creating the full-text index used by SearchUsers:
+//
+//	client.Schema.Create(ctx, comfyent.WithUserSearch())
+//
+// The index is filled from the existing users the first time it is created.
+// It relies on the FTS5 extension, which mattn/go-sqlite3 only compiles in with the
+// sqlite_fts5 build tag (go build -tags sqlite_fts5). Encrypted emails, see
+// Config.EncryptionKey, are indexed as their ciphertexts, so only names can be searched.
+func WithUserSearch() schema.MigrateOption {
+	return schema.WithApplyHook(func(next schema.Applier) schema.Applier {
+		return schema.ApplyFunc(func(ctx context.Context, conn dialect.ExecQuerier, plan *atlas.Plan) error {
+			if err := next.Apply(ctx, conn, plan); err != nil {
+				return err
+			}
+			return createSearchIndex(ctx, conn)
+		})
+	})
+}
+
+func createSearchIndex(ctx context.Context, conn dialect.ExecQuerier) error {
+	var rows sql.Rows
+	if err := conn.Query(ctx, "SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?", []any{searchTable}, &rows); err != nil {
+		return err
+	}
+	exists, err := sql.ScanBool(&rows)
+	if err != nil {
+		return err
+	}
+	for _, query := range searchDDL {
+		if err := conn.Exec(ctx, query, []any{}, nil); err != nil {
+			if strings.Contains(err.Error(), "no such module: fts5") {
+				return fmt.Errorf("creating user search index: FTS5 is unavailable, build with -tags sqlite_fts5: %w", err)
+			}
+			return fmt.Errorf("creating user search index: %w", err)
+		}
+	}
+	if !exists {
+		if err := conn.Exec(ctx, "INSERT INTO users_fts(users_fts) VALUES ('rebuild')", []any{}, nil); err != nil {
+			return fmt.Errorf("filling user search index: %w", err)
+		}
+	}
+	return nil
+}
+
+// SearchUsers returns the users whose name or email contain words starting with the words of
+// query, best matches first. The index must have been created with WithUserSearch.
+//
+// query is free text: punctuation is dropped rather than interpreted as FTS5 syntax,
+// and an empty query matches nobody.
+func SearchUsers(ctx context.Context, client *ent.Client, query string) ([]*ent.User, error) {
+	match := searchQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+	users, err := client.User.Query().
+		Where(searchMatch(match)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("searching users: %w", err)
+	}
+	return users, nil
+}
+
+// searchMatch joins the index to keep the users matching match, best matches first.
+func searchMatch(match string) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		t := sql.Table(searchTable)
+		s.Join(t).On(s.C(user.FieldID), t.C("rowid"))
+		s.Where(sql.ExprP(searchTable+" MATCH ?", match))
+		s.OrderExpr(sql.Expr(searchRank))
+	}
+}
+
+// searchRank ranks matches with bm25, lower is better. Name matches weigh ten times more
+// than email ones: a user named like the query comes before one whose email merely mentions it.
+const searchRank = "bm25(" + searchTable + ", 10.0, 1.0)"
+
+// UserHit is a user found by SearchUsersRanked, with its relevance: the higher, the better.
+type UserHit struct {
+	User  *ent.User
+	Score float64
+}
+
+// SearchUsersRanked is like SearchUsers, but returns at most limit users along with their
+// relevance, for search UIs to show. limit must be positive.
+func SearchUsersRanked(ctx context.Context, client *ent.Client, query string, limit int) ([]UserHit, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("search limit must be positive, got %d", limit)
+	}
+	match := searchQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+	q := client.User.Query().
+		Where(searchMatch(match)).
+		Limit(limit)
+	q.Modify(func(s *sql.Selector) {
+		s.AppendSelectExprAs(sql.Expr(searchRank), "score")
+	})
+	users, err := q.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("searching users: %w", err)
+	}
+	hits := make([]UserHit, len(users))
+	for i, u := range users {
+		v, err := u.Value("score")
+		if err != nil {
+			return nil, fmt.Errorf("reading score of user %d: %w", u.ID, err)
+		}
+		score, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("reading score of user %d: got %T, want float64", u.ID, v)
+		}
+		// Negate bm25 so scores grow with relevance.
+		hits[i] = UserHit{User: u, Score: -score}
+	}
+	return hits, nil
+}
+
+// searchQuery turns free text into an FTS5 query matching every word as a prefix.
+func searchQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = `"` + w + `"*`
+	}
+	return strings.Join(words, " ")
+}
+
+// SearchPredicate matches the users whose name or email contain term, ignoring case.
+// Unlike SearchUsers it needs no index and matches anywhere within words, at the cost of
+// a full scan; it composes with other predicates in Where. Encrypted emails, see
+// Config.EncryptionKey, never match.
+func SearchPredicate(term string) predicate.User {
+	return user.Or(
+		user.NameContainsFold(term),
+		user.EmailContainsFold(term),
+	)
+}
@@ -0,0 +1,82 @@
+//go:build sqlite_fts5
+
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// newSearchClient returns a test client with the user search index, and users named after
+// the query "ada" or only mentioning it in their email.
+func newSearchClient(t *testing.T) (*ent.Client, func()) {
+	t.Helper()
+	client, cleanup := comfyenttest.NewTestClient(t)
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx, comfyent.WithUserSearch()); err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	client.User.Create().SetName("bob").SetAge(30).SetEmail("ada.fan@example.com").ExecX(ctx)
+	client.User.Create().SetName("ada lovelace").SetAge(36).SetEmail("countess@example.com").ExecX(ctx)
+	client.User.Create().SetName("grace").SetAge(40).SetEmail("grace@example.com").ExecX(ctx)
+	return client, cleanup
+}
+
+func TestSearchUsers(t *testing.T) {
+	client, cleanup := newSearchClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	users, err := comfyent.SearchUsers(ctx, client, "Ada!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("got %d users, want the two mentioning ada", len(users))
+	}
+	if users[0].Name != "ada lovelace" || users[1].Name != "bob" {
+		t.Fatalf("got %s before %s, want the name match before the email one", users[0].Name, users[1].Name)
+	}
+
+	// Prefixes match, and updates reach the index.
+	client.User.Update().SetName("grace hopper").ExecX(ctx)
+	if users, err = comfyent.SearchUsers(ctx, client, "hop"); err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("got %d users for hop, want the 3 renamed ones", len(users))
+	}
+
+	if users, err = comfyent.SearchUsers(ctx, client, " ?! "); err != nil || users != nil {
+		t.Fatalf("got %v, %v for a query without words, want nobody", users, err)
+	}
+}
+
+func TestSearchUsersRanked(t *testing.T) {
+	client, cleanup := newSearchClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	hits, err := comfyent.SearchUsersRanked(ctx, client, "ada", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 2 || hits[0].User.Name != "ada lovelace" || hits[1].User.Name != "bob" {
+		t.Fatalf("got %d hits, want the name match then the email one", len(hits))
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Fatalf("got scores %f then %f, want them decreasing", hits[0].Score, hits[1].Score)
+	}
+
+	if hits, err = comfyent.SearchUsersRanked(ctx, client, "ada", 1); err != nil || len(hits) != 1 || hits[0].User.Name != "ada lovelace" {
+		t.Fatalf("got %v, %v with a limit of 1, want the best hit", hits, err)
+	}
+	if _, err := comfyent.SearchUsersRanked(ctx, client, "ada", 0); err == nil {
+		t.Fatal("searched with a zero limit")
+	}
+}
@@ -0,0 +1,37 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestSearchPredicate(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	client.User.Create().SetName("Marie Curie").SetAge(30).SetEmail("mc@example.com").ExecX(ctx)
+	client.User.Create().SetName("bob").SetAge(40).SetEmail("curie.fan@example.com").ExecX(ctx)
+	client.User.Create().SetName("grace").SetAge(50).SetEmail("grace@example.com").ExecX(ctx)
+
+	names := client.User.Query().
+		Where(comfyent.SearchPredicate("CURIE")).
+		Order(user.ByName()).
+		Select(user.FieldName).
+		StringsX(ctx)
+	if len(names) != 2 || names[0] != "Marie Curie" || names[1] != "bob" {
+		t.Fatalf("got %q, want the name match and the email match", names)
+	}
+
+	// It composes with other predicates.
+	names = client.User.Query().
+		Where(comfyent.SearchPredicate("curie"), user.AgeGT(35)).
+		Select(user.FieldName).
+		StringsX(ctx)
+	if len(names) != 1 || names[0] != "bob" {
+		t.Fatalf("got %q, want bob only", names)
+	}
+}
@@ -0,0 +1,51 @@
+// Package seed loads sample users into a database, for tests and local development.
+package seed
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+//go:embed fixtures/users.json
+var fixtures []byte
+
+// UserSeed describes a user to seed.
+type UserSeed struct {
+	Name  string `json:"name"`
+	Age   int    `json:"age"`
+	Email string `json:"email"`
+}
+
+// Seed inserts the given users, updating the existing ones sharing the same email,
+// so running it several times is idempotent.
+func Seed(ctx context.Context, client *ent.Client, users []UserSeed) error {
+	inputs := make([]comfyent.UserInput, len(users))
+	for i, u := range users {
+		inputs[i] = comfyent.UserInput(u)
+	}
+	if err := comfyent.UpsertUsers(ctx, client, inputs); err != nil {
+		return fmt.Errorf("seeding users: %w", err)
+	}
+	return nil
+}
+
+// SeedFromJSON seeds the users decoded from a JSON array read from r.
+func SeedFromJSON(ctx context.Context, client *ent.Client, r io.Reader) error {
+	var users []UserSeed
+	if err := json.NewDecoder(r).Decode(&users); err != nil {
+		return fmt.Errorf("decoding seed users: %w", err)
+	}
+	return Seed(ctx, client, users)
+}
+
+// SeedFixtures seeds the sample users shipped in fixtures/users.json.
+func SeedFixtures(ctx context.Context, client *ent.Client) error {
+	return SeedFromJSON(ctx, client, bytes.NewReader(fixtures))
+}
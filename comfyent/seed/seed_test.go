@@ -0,0 +1,61 @@
+package seed_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/seed"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestSeedFixturesTwice(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if err := seed.SeedFixtures(ctx, client); err != nil {
+			t.Fatalf("seeding fixtures, run %d: %v", i+1, err)
+		}
+	}
+	if n := client.User.Query().CountX(ctx); n != 4 {
+		t.Fatalf("got %d users after seeding twice, want 4", n)
+	}
+}
+
+func TestSeedUpdatesExisting(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := seed.Seed(ctx, client, []seed.UserSeed{{Name: "Alice", Age: 30, Email: "alice@example.com"}}); err != nil {
+		t.Fatalf("seeding: %v", err)
+	}
+	if err := seed.Seed(ctx, client, []seed.UserSeed{{Name: "Alicia", Age: 31, Email: "alice@example.com"}}); err != nil {
+		t.Fatalf("seeding again: %v", err)
+	}
+	u := client.User.Query().Where(user.EmailEQ("alice@example.com")).OnlyX(ctx)
+	if u.Name != "Alicia" || u.Age != 31 {
+		t.Fatalf("got %s aged %d, want Alicia aged 31", u.Name, u.Age)
+	}
+}
+
+func TestSeedFromJSON(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	r := strings.NewReader(`[{"name": "Eve", "age": 40, "email": "eve@example.com"}]`)
+	if err := seed.SeedFromJSON(ctx, client, r); err != nil {
+		t.Fatalf("SeedFromJSON: %v", err)
+	}
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users, want 1", n)
+	}
+
+	if err := seed.SeedFromJSON(ctx, client, strings.NewReader(`{`)); err == nil {
+		t.Fatal("SeedFromJSON succeeded on malformed JSON")
+	}
+}
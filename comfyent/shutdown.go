@@ -0,0 +1,95 @@
+package comfyent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// ErrShutdown is returned for statements started after Shutdown was called.
+var ErrShutdown = errors.New("comfyent: database is shutting down")
+
+// inflights holds the inflight tracker of every ComfyDB opened with OpenDB.
+var inflights sync.Map
+
+// inflight counts the statements, open rows and transactions running against a ComfyDB.
+type inflight struct {
+	mu      sync.Mutex
+	n       int
+	closing bool
+	idle    chan struct{}
+}
+
+// inflightOf returns the tracker shared by every *sql.DB opened on comfy.
+func inflightOf(comfy *comfylite3.ComfyDB) *inflight {
+	v, _ := inflights.LoadOrStore(comfy, &inflight{})
+	return v.(*inflight)
+}
+
+// acquire registers new work, refusing it once shutting down unless it belongs
+// to work already accepted (e.g. a statement within an open transaction).
+func (f *inflight) acquire(accepted bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closing && !accepted {
+		return ErrShutdown
+	}
+	f.n++
+	return nil
+}
+
+// release marks work as done. release must be called once per successful acquire.
+func (f *inflight) release() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.n--
+	if f.n == 0 && f.idle != nil {
+		close(f.idle)
+		f.idle = nil
+	}
+}
+
+// drain stops accepting new work and waits for the running work to be done,
+// returning how much is still running if ctx is done first.
+func (f *inflight) drain(ctx context.Context) (int, error) {
+	f.mu.Lock()
+	f.closing = true
+	if f.n == 0 {
+		f.mu.Unlock()
+		return 0, nil
+	}
+	if f.idle == nil {
+		f.idle = make(chan struct{})
+	}
+	idle := f.idle
+	f.mu.Unlock()
+
+	select {
+	case <-idle:
+		return 0, nil
+	case <-ctx.Done():
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return f.n, ctx.Err()
+	}
+}
+
+// Shutdown stops accepting new statements on the databases opened on comfy with OpenDB,
+// waits for the running ones (including open rows and transactions) to finish, then closes
+// client and comfy in that order.
+//
+// If ctx is done before everything finished, both are closed anyway and the returned error
+// reports how many queries were still in flight.
+func Shutdown(ctx context.Context, client *ent.Client, comfy *comfylite3.ComfyDB) error {
+	pending, waitErr := inflightOf(comfy).drain(ctx)
+	inflights.Delete(comfy)
+	closeErr := errors.Join(client.Close(), comfy.Close())
+	if waitErr != nil {
+		return errors.Join(fmt.Errorf("shutdown with %d queries still in flight: %w", pending, waitErr), closeErr)
+	}
+	return closeErr
+}
@@ -0,0 +1,70 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+func TestShutdownWaitsForQueries(t *testing.T) {
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.SQLite, comfyent.OpenDB(comfy))))
+	ctx := context.Background()
+
+	// Rows left open keep their query in flight, as a slow query would.
+	rows, err := client.QueryContext(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- comfyent.Shutdown(ctx, client, comfy)
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown returned %v with a query in flight", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if _, err := client.QueryContext(ctx, "SELECT 1"); !errors.Is(err, comfyent.ErrShutdown) {
+		t.Fatalf("got error %v querying while shutting down, want ErrShutdown", err)
+	}
+
+	rows.Close()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Shutdown: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown still waiting after the query finished")
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.SQLite, comfyent.OpenDB(comfy))))
+	rows, err := client.QueryContext(context.Background(), "SELECT 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := comfyent.Shutdown(ctx, client, comfy); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v shutting down with a query in flight, want a deadline error", err)
+	}
+}
@@ -0,0 +1,122 @@
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// TableStat describes the size of a table.
+type TableStat struct {
+	Name string
+	Rows int64
+	// Bytes is the space the table and its indexes take in the file. When Estimated is set,
+	// it was computed from the length of the values stored instead, leaving out indexes and
+	// page overhead.
+	Bytes     int64
+	Estimated bool
+}
+
+// TableStats returns the size of every table of the database, ordered by name. Sizes come
+// from the dbstat virtual table, which SQLite only provides when compiled with
+// SQLITE_ENABLE_DBSTAT_VTAB; without it they are estimated. Either way, every row of every
+// table is read, so it's best run off-peak.
+func TableStats(ctx context.Context, db *sql.DB) ([]TableStat, error) {
+	rows, err := db.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name;")
+	if err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+	var stats []TableStat
+	for rows.Next() {
+		var st TableStat
+		if err := rows.Scan(&st.Name); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("listing tables: %w", err)
+		}
+		stats = append(stats, st)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+
+	sizes, err := dbstatSizes(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for i := range stats {
+		st := &stats[i]
+		if err := db.QueryRowContext(ctx, "SELECT count(*) FROM "+quoteIdent(st.Name)+";").Scan(&st.Rows); err != nil {
+			return nil, fmt.Errorf("counting rows of %s: %w", st.Name, err)
+		}
+		if sizes != nil {
+			st.Bytes = sizes[st.Name]
+			continue
+		}
+		if st.Bytes, err = estimateTableSize(ctx, db, st.Name); err != nil {
+			return nil, err
+		}
+		st.Estimated = true
+	}
+	return stats, nil
+}
+
+// dbstatSizes returns the bytes taken by each table and its indexes according to dbstat,
+// or nil if dbstat is unavailable.
+func dbstatSizes(ctx context.Context, db *sql.DB) (map[string]int64, error) {
+	rows, err := db.QueryContext(ctx, `SELECT m.tbl_name, sum(s.pgsize) FROM dbstat s
+		JOIN sqlite_master m ON m.name = s.name GROUP BY m.tbl_name;`)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such table: dbstat") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading dbstat: %w", err)
+	}
+	defer rows.Close()
+	sizes := map[string]int64{}
+	for rows.Next() {
+		var (
+			name string
+			size int64
+		)
+		if err := rows.Scan(&name, &size); err != nil {
+			return nil, fmt.Errorf("reading dbstat: %w", err)
+		}
+		sizes[name] = size
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading dbstat: %w", err)
+	}
+	return sizes, nil
+}
+
+// estimateTableSize sums the length of the values stored in table.
+func estimateTableSize(ctx context.Context, db *sql.DB, table string) (int64, error) {
+	rows, err := db.QueryContext(ctx, "SELECT name FROM pragma_table_info(?);", table)
+	if err != nil {
+		return 0, fmt.Errorf("listing columns of %s: %w", table, err)
+	}
+	var lengths []string
+	for rows.Next() {
+		var col string
+		if err := rows.Scan(&col); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("listing columns of %s: %w", table, err)
+		}
+		lengths = append(lengths, "coalesce(length(CAST("+quoteIdent(col)+" AS BLOB)), 0)")
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("listing columns of %s: %w", table, err)
+	}
+	if len(lengths) == 0 {
+		return 0, nil
+	}
+	var size int64
+	query := "SELECT coalesce(sum(" + strings.Join(lengths, " + ") + "), 0) FROM " + quoteIdent(table) + ";"
+	if err := db.QueryRowContext(ctx, query).Scan(&size); err != nil {
+		return 0, fmt.Errorf("estimating size of %s: %w", table, err)
+	}
+	return size, nil
+}
@@ -0,0 +1,36 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestTableStats(t *testing.T) {
+	client, comfy := newFileClient(t)
+	createUsers(t, client, "a@example.com", "b@example.com", "c@example.com")
+	db := comfyent.OpenDB(comfy)
+	defer db.Close()
+
+	stats, err := comfyent.TableStats(context.Background(), db)
+	if err != nil {
+		t.Fatalf("TableStats: %v", err)
+	}
+	var found bool
+	for i, st := range stats {
+		if i > 0 && stats[i-1].Name >= st.Name {
+			t.Fatalf("got table %s after %s, want name order", st.Name, stats[i-1].Name)
+		}
+		if st.Name != "users" {
+			continue
+		}
+		found = true
+		if st.Rows != 3 || st.Bytes <= 0 {
+			t.Fatalf("got %d rows taking %d bytes in users, want 3 taking some", st.Rows, st.Bytes)
+		}
+	}
+	if !found {
+		t.Fatalf("got tables %+v, want users among them", stats)
+	}
+}
@@ -0,0 +1,60 @@
+package comfyent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/hook"
+	"github.com/davidroman0O/comfylite3-ent/ent/intercept"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// ErrMissingTenant is returned for user queries and mutations run without a tenant by a client
+// with tenancy enabled.
+var ErrMissingTenant = errors.New("no tenant in context")
+
+type tenantKey struct{}
+
+// WithTenant returns a context whose user queries and mutations are scoped to tenant by a
+// client with tenancy enabled.
+func WithTenant(parent context.Context, tenant string) context.Context {
+	return context.WithValue(parent, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set by WithTenant, if any.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok && tenant != ""
+}
+
+// EnableTenancy scopes the users of client, and of the transactions it starts, to the tenant
+// of the context: queries, edge traversals included, only see the users of that tenant,
+// updates and deletes only touch them, and creates stamp it on the new users. Without a
+// tenant in the context, they all fail with ErrMissingTenant.
+//
+// Emails stay unique across tenants.
+func EnableTenancy(client *ent.Client) {
+	client.User.Intercept(intercept.TraverseUser(func(ctx context.Context, q *ent.UserQuery) error {
+		tenant, ok := TenantFromContext(ctx)
+		if !ok {
+			return ErrMissingTenant
+		}
+		q.Where(user.TenantID(tenant))
+		return nil
+	}))
+	client.User.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+			tenant, ok := TenantFromContext(ctx)
+			if !ok {
+				return nil, ErrMissingTenant
+			}
+			if m.Op().Is(ent.OpCreate) {
+				m.SetTenantID(tenant)
+			} else {
+				m.Where(user.TenantID(tenant))
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+}
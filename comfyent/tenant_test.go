@@ -0,0 +1,47 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+func TestEnableTenancy(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	comfyent.EnableTenancy(client)
+	acme := comfyent.WithTenant(context.Background(), "acme")
+	globex := comfyent.WithTenant(context.Background(), "globex")
+
+	a := client.User.Create().SetName("a").SetAge(30).SetEmail("a@acme.com").SaveX(acme)
+	client.User.Create().SetName("b").SetAge(30).SetEmail("b@acme.com").ExecX(acme)
+	g := client.User.Create().SetName("g").SetAge(30).SetEmail("g@globex.com").SaveX(globex)
+	if a.TenantID != "acme" {
+		t.Fatalf("got tenant %q, want acme stamped on the user", a.TenantID)
+	}
+
+	if n := client.User.Query().CountX(acme); n != 2 {
+		t.Fatalf("acme sees %d users, want its 2", n)
+	}
+	if n := client.User.Query().CountX(globex); n != 1 {
+		t.Fatalf("globex sees %d users, want its 1", n)
+	}
+	if _, err := client.User.Get(acme, g.ID); !ent.IsNotFound(err) {
+		t.Fatalf("got %v reading globex's user as acme, want not found", err)
+	}
+	if err := client.User.UpdateOneID(g.ID).SetAge(31).Exec(acme); !ent.IsNotFound(err) {
+		t.Fatalf("got %v updating globex's user as acme, want not found", err)
+	}
+
+	ctx := context.Background()
+	if err := client.User.Create().SetName("c").SetAge(30).SetEmail("c@example.com").Exec(ctx); !errors.Is(err, comfyent.ErrMissingTenant) {
+		t.Fatalf("got %v creating a user without a tenant, want ErrMissingTenant", err)
+	}
+	if _, err := client.User.Query().All(ctx); !errors.Is(err, comfyent.ErrMissingTenant) {
+		t.Fatalf("got %v querying without a tenant, want ErrMissingTenant", err)
+	}
+}
@@ -0,0 +1,14 @@
+package comfyent
+
+import (
+	"context"
+	"time"
+)
+
+// WithQueryTimeout returns a context cancelled after d, to bound the ent calls it's passed to:
+// every client, query and mutation method accepts it, and the statement running when
+// it expires is interrupted. To enforce a default timeout on every statement instead,
+// wrap the driver with driver.NewTimeoutDriver.
+func WithQueryTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, d)
+}
@@ -0,0 +1,128 @@
+// Package tracing emits OpenTelemetry spans for the statements ent sends to comfylite3.
+package tracing
+
+import (
+	"context"
+	"database/sql"
+
+	"entgo.io/ent/dialect"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/driver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Driver is a dialect.Driver starting a span for every Exec, Query and transaction.
+type Driver struct {
+	*driver.Driver
+	tracer trace.Tracer
+}
+
+// NewTracingDriver returns a driver tracing the operations of inner with tracer.
+// Statements are recorded as-is; bind arguments are never recorded, only their count.
+func NewTracingDriver(inner dialect.Driver, tracer trace.Tracer) *Driver {
+	d := &Driver{tracer: tracer}
+	d.Driver = driver.Wrap(inner, d.trace)
+	return d
+}
+
+// Tx starts a transaction traced by a span ending on commit or rollback.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	ctx, span := d.start(ctx, "comfyent.tx")
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		end(span, err)
+		return nil, err
+	}
+	return &Tx{Tx: tx, span: span}, nil
+}
+
+// BeginTx starts a transaction with options traced by a span ending on commit or rollback.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	ctx, span := d.start(ctx, "comfyent.tx")
+	tx, err := d.Driver.BeginTx(ctx, opts)
+	if err != nil {
+		end(span, err)
+		return nil, err
+	}
+	return &Tx{Tx: tx, span: span}, nil
+}
+
+func (d *Driver) trace(next driver.Handler) driver.Handler {
+	return func(ctx context.Context, stmt driver.Statement, v any) error {
+		ctx, span := d.start(ctx, "comfyent."+string(stmt.Op),
+			attribute.String("db.statement", stmt.Query),
+			attribute.String("db.operation", driver.Keyword(stmt.Query)),
+			attribute.Int("db.args.count", argsCount(stmt.Args)),
+			attribute.Bool("db.in_tx", stmt.InTx),
+		)
+		err := next(ctx, stmt, v)
+		end(span, err)
+		return err
+	}
+}
+
+func (d *Driver) start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return d.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, attribute.String("db.system", "sqlite"))...),
+	)
+}
+
+// Tx is a traced transaction. The spans of its statements are children of its own.
+type Tx struct {
+	dialect.Tx
+	span trace.Span
+}
+
+// Exec runs an Exec statement within the transaction.
+func (t *Tx) Exec(ctx context.Context, query string, args, v any) error {
+	return t.Tx.Exec(trace.ContextWithSpan(ctx, t.span), query, args, v)
+}
+
+// Query runs a Query statement within the transaction.
+func (t *Tx) Query(ctx context.Context, query string, args, v any) error {
+	return t.Tx.Query(trace.ContextWithSpan(ctx, t.span), query, args, v)
+}
+
+// ExecContext runs an Exec statement within the transaction, for ent's ExecContext.
+func (t *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.Tx.(*driver.Tx).ExecContext(trace.ContextWithSpan(ctx, t.span), query, args...)
+}
+
+// QueryContext runs a Query statement within the transaction, for ent's QueryContext.
+func (t *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.Tx.(*driver.Tx).QueryContext(trace.ContextWithSpan(ctx, t.span), query, args...)
+}
+
+// Commit commits the transaction and ends its span.
+func (t *Tx) Commit() error {
+	err := t.Tx.Commit()
+	end(t.span, err)
+	return err
+}
+
+// Rollback rolls the transaction back and ends its span.
+func (t *Tx) Rollback() error {
+	err := t.Tx.Rollback()
+	t.span.SetAttributes(attribute.Bool("db.rollback", true))
+	end(t.span, err)
+	return err
+}
+
+// end records err on span, if any, and ends it.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// argsCount returns the number of bind arguments given to a statement.
+func argsCount(args any) int {
+	if args, ok := args.([]any); ok {
+		return len(args)
+	}
+	return 0
+}
@@ -0,0 +1,35 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent/schema"
+)
+
+func TestTruncateAll(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	users := createManyUsers(t, client, 5)
+	client.Phone.Create().SetNumber("+33100000000").SetLabel("home").SetOwner(users[0]).ExecX(ctx)
+
+	if err := comfyent.TruncateAll(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	ctx = schema.SkipSoftDelete(ctx)
+	if n := client.User.Query().CountX(ctx); n != 0 {
+		t.Fatalf("got %d users, want 0", n)
+	}
+	if n := client.Phone.Query().CountX(ctx); n != 0 {
+		t.Fatalf("got %d phones, want 0", n)
+	}
+	if n := client.AuditLog.Query().CountX(ctx); n != 0 {
+		t.Fatalf("got %d audit rows, want 0", n)
+	}
+	if u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx); u.ID != 1 {
+		t.Fatalf("got ID %d after truncating, want 1", u.ID)
+	}
+}
@@ -0,0 +1,47 @@
+package tx
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// savepoints numbers savepoints so nested calls never reuse a name.
+var savepoints atomic.Uint64
+
+// WithNestedTx runs fn within a savepoint of the already open tx: when fn fails or panics,
+// only its changes are rolled back, and tx stays usable by the caller. It composes with
+// itself, an inner WithNestedTx call creating a savepoint within the outer one.
+//
+// Unlike calling WithTx from within a transaction, it doesn't need another connection.
+func WithNestedTx(ctx context.Context, tx *ent.Tx, fn func(tx *ent.Tx) error) error {
+	name := fmt.Sprintf("comfyent_sp_%d", savepoints.Add(1))
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("creating savepoint: %w", err)
+	}
+	rollback := func() error {
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO "+name); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "RELEASE "+name)
+		return err
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			rollback()
+			panic(v)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rerr := rollback(); rerr != nil {
+			err = fmt.Errorf("rolling back to savepoint: %w", rerr)
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "RELEASE "+name); err != nil {
+		return fmt.Errorf("releasing savepoint: %w", err)
+	}
+	return nil
+}
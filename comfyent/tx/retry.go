@@ -0,0 +1,50 @@
+package tx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/mattn/go-sqlite3"
+)
+
+// RetryOptions configures how WithTxRetry retries a transaction.
+type RetryOptions struct {
+	// MaxAttempts is the total number of times the closure is run. Values below 1 are treated as 1.
+	MaxAttempts int
+	// Backoff is the time waited after a failed attempt before trying again.
+	Backoff time.Duration
+}
+
+// WithTxRetry wraps the given function in a transaction and retries it from scratch
+// when SQLite reports the database as busy or locked.
+// Any other error is returned right away.
+func WithTxRetry(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error, opts RetryOptions) error {
+	attempts := max(opts.MaxAttempts, 1)
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = WithTx(ctx, client, fn); err == nil || !isBusy(err) {
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("retrying transaction after %d attempts: %w", attempt, ctx.Err())
+		case <-time.After(opts.Backoff):
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
+
+// isBusy reports whether err is a SQLITE_BUSY or SQLITE_LOCKED error.
+func isBusy(err error) bool {
+	var serr sqlite3.Error
+	if !errors.As(err, &serr) {
+		return false
+	}
+	return serr.Code == sqlite3.ErrBusy || serr.Code == sqlite3.ErrLocked
+}
@@ -0,0 +1,169 @@
+package tx_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/tx"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/mattn/go-sqlite3"
+)
+
+var errBusy = sqlite3.Error{Code: sqlite3.ErrBusy}
+
+func TestWithTxRetryRetriesBusy(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	attempts := 0
+	err := tx.WithTxRetry(ctx, client, func(etx *ent.Tx) error {
+		attempts++
+		if err := createUser(ctx, etx, "a@example.com"); err != nil {
+			return err
+		}
+		if attempts < 3 {
+			return errBusy
+		}
+		return nil
+	}, tx.RetryOptions{MaxAttempts: 5, Backoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WithTxRetry: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("ran %d attempts, want 3", attempts)
+	}
+	// The failed attempts were rolled back.
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users, want 1", n)
+	}
+}
+
+func TestWithTxRetryGivesUp(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+
+	attempts := 0
+	err := tx.WithTxRetry(context.Background(), client, func(*ent.Tx) error {
+		attempts++
+		return sqlite3.Error{Code: sqlite3.ErrLocked}
+	}, tx.RetryOptions{MaxAttempts: 3})
+	var serr sqlite3.Error
+	if !errors.As(err, &serr) || serr.Code != sqlite3.ErrLocked {
+		t.Fatalf("got error %v, want SQLITE_LOCKED", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("ran %d attempts, want 3", attempts)
+	}
+}
+
+func TestWithTxRetryReturnsOtherErrors(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+
+	errBoom := errors.New("boom")
+	attempts := 0
+	err := tx.WithTxRetry(context.Background(), client, func(*ent.Tx) error {
+		attempts++
+		return errBoom
+	}, tx.RetryOptions{MaxAttempts: 3})
+	if !errors.Is(err, errBoom) || attempts != 1 {
+		t.Fatalf("got %v after %d attempts, want %v after 1", err, attempts, errBoom)
+	}
+}
+
+func TestWithTxRetryStopsOnCancel(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	err := tx.WithTxRetry(ctx, client, func(*ent.Tx) error {
+		attempts++
+		cancel()
+		return errBusy
+	}, tx.RetryOptions{MaxAttempts: 3, Backoff: time.Hour})
+	if !errors.Is(err, context.Canceled) || attempts != 1 {
+		t.Fatalf("got %v after %d attempts, want context.Canceled after 1", err, attempts)
+	}
+}
+
+func TestWithTxRetryContention(t *testing.T) {
+	// Plain mattn connections, one per transaction, failing right away on locks instead of
+	// waiting for them.
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "test.db")+"?_busy_timeout=0&_fk=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+
+	var (
+		attempts, busy atomic.Int32
+		read           sync.WaitGroup
+		wg             sync.WaitGroup
+		errs           = make([]error, 2)
+	)
+	// isBusy counts the real busy or locked errors SQLite returns.
+	isBusy := func(err error) error {
+		var serr sqlite3.Error
+		if errors.As(err, &serr) && (serr.Code == sqlite3.ErrBusy || serr.Code == sqlite3.ErrLocked) {
+			busy.Add(1)
+		}
+		return err
+	}
+	read.Add(2)
+	for g := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			first := true
+			errs[g] = tx.WithTxRetry(ctx, client, func(etx *ent.Tx) error {
+				attempts.Add(1)
+				etx.OnCommit(func(next ent.Committer) ent.Committer {
+					return ent.CommitFunc(func(ctx context.Context, etx *ent.Tx) error {
+						return isBusy(next.Commit(ctx, etx))
+					})
+				})
+				cur, err := etx.User.Get(ctx, u.ID)
+				if err != nil {
+					return err
+				}
+				// Both transactions read the row before either writes it.
+				if first {
+					first = false
+					read.Done()
+					read.Wait()
+				}
+				return isBusy(etx.User.UpdateOneID(u.ID).SetAge(cur.Age + 1).Exec(ctx))
+			}, tx.RetryOptions{MaxAttempts: 20, Backoff: 5 * time.Millisecond})
+		}()
+	}
+	wg.Wait()
+	for g, err := range errs {
+		if err != nil {
+			t.Fatalf("transaction %d: %v", g, err)
+		}
+	}
+	if busy.Load() == 0 || attempts.Load() <= 2 {
+		t.Fatalf("got %d busy errors in %d attempts, want the contention to be retried", busy.Load(), attempts.Load())
+	}
+	// Both increments made it, neither overwrote the other.
+	if got := client.User.GetX(ctx, u.ID); got.Age != 32 {
+		t.Fatalf("got age %d, want 32", got.Age)
+	}
+}
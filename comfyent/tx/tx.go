@@ -0,0 +1,67 @@
+// Package tx provides reusable transaction helpers for ent clients backed by comfylite3.
+package tx
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// WithTx wraps the given function in a transaction.
+func WithTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	return run(tx, fn)
+}
+
+// WithTxOptions wraps the given function in a transaction started with the given options.
+//
+// SQLite only honors the ReadOnly flag; every transaction is effectively serializable
+// whatever Isolation is requested. The options are still passed down to the driver so
+// callers don't have to change anything if it ever gains support for them.
+func WithTxOptions(ctx context.Context, client *ent.Client, opts *sql.TxOptions, fn func(tx *ent.Tx) error) error {
+	tx, err := client.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return run(tx, fn)
+}
+
+// WithTxResult wraps the given function in a transaction and returns the value it produced.
+func WithTxResult[T any](ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) (T, error)) (T, error) {
+	var result T
+	err := WithTx(ctx, client, func(tx *ent.Tx) error {
+		var err error
+		result, err = fn(tx)
+		return err
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return result, nil
+}
+
+// run executes fn within tx, rolling back on error or panic and committing otherwise.
+func run(tx *ent.Tx, fn func(tx *ent.Tx) error) error {
+	defer func() {
+		if v := recover(); v != nil {
+			tx.Rollback()
+			panic(v)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("rolling back transaction: %w", rerr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package tx_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/tx"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func createUser(ctx context.Context, etx *ent.Tx, email string) error {
+	return etx.User.Create().SetName("a").SetAge(30).SetEmail(email).Exec(ctx)
+}
+
+func TestWithTxCommits(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	err := tx.WithTx(ctx, client, func(etx *ent.Tx) error {
+		return createUser(ctx, etx, "a@example.com")
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users, want 1", n)
+	}
+}
+
+func TestWithTxRollsBackOnError(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	errBoom := errors.New("boom")
+	err := tx.WithTx(ctx, client, func(etx *ent.Tx) error {
+		if err := createUser(ctx, etx, "a@example.com"); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("got error %v, want %v", err, errBoom)
+	}
+	if n := client.User.Query().CountX(ctx); n != 0 {
+		t.Fatalf("got %d users after rollback, want 0", n)
+	}
+}
+
+func TestWithTxRollsBackOnPanic(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	func() {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Fatalf("got panic %v, want boom", v)
+			}
+		}()
+		tx.WithTx(ctx, client, func(etx *ent.Tx) error {
+			if err := createUser(ctx, etx, "a@example.com"); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+	if n := client.User.Query().CountX(ctx); n != 0 {
+		t.Fatalf("got %d users after panic, want 0", n)
+	}
+}
+
+func TestWithTxResult(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	u, err := tx.WithTxResult(ctx, client, func(etx *ent.Tx) (*ent.User, error) {
+		return etx.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").Save(ctx)
+	})
+	if err != nil {
+		t.Fatalf("WithTxResult: %v", err)
+	}
+	if got := client.User.GetX(ctx, u.ID); got.Email != "a@example.com" {
+		t.Fatalf("got email %q, want a@example.com", got.Email)
+	}
+
+	errBoom := errors.New("boom")
+	u, err = tx.WithTxResult(ctx, client, func(etx *ent.Tx) (*ent.User, error) {
+		u, err := etx.User.Create().SetName("b").SetAge(30).SetEmail("b@example.com").Save(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return u, errBoom
+	})
+	if !errors.Is(err, errBoom) || u != nil {
+		t.Fatalf("got %v, %v, want nil, %v", u, err, errBoom)
+	}
+}
+
+func TestWithTxOptions(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	opts := &sql.TxOptions{Isolation: sql.LevelSerializable}
+	if err := tx.WithTxOptions(ctx, client, opts, func(etx *ent.Tx) error {
+		return createUser(ctx, etx, "a@example.com")
+	}); err != nil {
+		t.Fatalf("WithTxOptions: %v", err)
+	}
+	errBoom := errors.New("boom")
+	err := tx.WithTxOptions(ctx, client, opts, func(etx *ent.Tx) error {
+		if err := createUser(ctx, etx, "b@example.com"); err != nil {
+			return err
+		}
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("got error %v, want %v", err, errBoom)
+	}
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users, want the committed one only", n)
+	}
+}
+
+func TestWithNestedTx(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	errBoom := errors.New("boom")
+	err := tx.WithTx(ctx, client, func(etx *ent.Tx) error {
+		if err := createUser(ctx, etx, "outer@example.com"); err != nil {
+			return err
+		}
+		err := tx.WithNestedTx(ctx, etx, func(etx *ent.Tx) error {
+			if err := createUser(ctx, etx, "inner@example.com"); err != nil {
+				return err
+			}
+			return errBoom
+		})
+		if !errors.Is(err, errBoom) {
+			t.Errorf("got error %v from the savepoint, want %v", err, errBoom)
+		}
+		return createUser(ctx, etx, "after@example.com")
+	})
+	if err != nil {
+		t.Fatalf("WithTx: %v", err)
+	}
+	emails := client.User.Query().Order(user.ByEmail()).Select(user.FieldEmail).StringsX(ctx)
+	if len(emails) != 2 || emails[0] != "after@example.com" || emails[1] != "outer@example.com" {
+		t.Fatalf("got users %q, want the outer ones only", emails)
+	}
+}
@@ -0,0 +1,58 @@
+package comfyent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+	"github.com/mattn/go-sqlite3"
+)
+
+// ErrEmailTaken is returned when an email already belongs to another user.
+var ErrEmailTaken = errors.New("email already in use")
+
+// uniqueIndexFields maps the unique expression indexes, reported by name rather than by
+// column, to the field they guard.
+var uniqueIndexFields = map[string]string{
+	"users_email_lower_key": user.FieldEmail,
+}
+
+// IsUniqueViolation reports whether err was caused by a unique constraint, and which field
+// failed, e.g. "email". Composite constraints report their columns joined by commas.
+func IsUniqueViolation(err error) (field string, ok bool) {
+	var serr sqlite3.Error
+	if !errors.As(err, &serr) || serr.ExtendedCode != sqlite3.ErrConstraintUnique && serr.ExtendedCode != sqlite3.ErrConstraintPrimaryKey {
+		return "", false
+	}
+	// SQLite reports "UNIQUE constraint failed: users.email, users.name"
+	// or, for expression indexes, "UNIQUE constraint failed: index 'users_email_lower_key'".
+	_, target, _ := strings.Cut(serr.Error(), "failed: ")
+	if index, ok := strings.CutPrefix(target, "index "); ok {
+		index = strings.Trim(index, `'"`)
+		if f, ok := uniqueIndexFields[index]; ok {
+			return f, true
+		}
+		return index, true
+	}
+	columns := strings.Split(target, ", ")
+	for i, c := range columns {
+		_, columns[i], _ = strings.Cut(c, ".")
+	}
+	return strings.Join(columns, ","), true
+}
+
+// CreateUser creates the user described by input, failing with ErrEmailTaken rather than a
+// raw constraint error when its email is already used.
+func CreateUser(ctx context.Context, client *ent.Client, input UserInput) (*ent.User, error) {
+	u, err := input.builder(client).Save(ctx)
+	if field, ok := IsUniqueViolation(err); ok && field == user.FieldEmail {
+		return nil, fmt.Errorf("creating user %s: %w", input.Email, ErrEmailTaken)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating user: %w", err)
+	}
+	return u, nil
+}
@@ -0,0 +1,34 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").ExecX(ctx)
+
+	err := client.User.Create().SetName("b").SetAge(40).SetEmail("a@example.com").Exec(ctx)
+	if field, ok := comfyent.IsUniqueViolation(err); !ok || field != "email" {
+		t.Fatalf("got %q, %v for %v, want the email", field, ok, err)
+	}
+	if _, ok := comfyent.IsUniqueViolation(errors.New("UNIQUE constraint failed: users.email")); ok {
+		t.Fatal("got a unique violation out of a plain error")
+	}
+	if _, ok := comfyent.IsUniqueViolation(nil); ok {
+		t.Fatal("got a unique violation out of nil")
+	}
+
+	// CreateUser turns it into ErrEmailTaken.
+	_, err = comfyent.CreateUser(ctx, client, comfyent.UserInput{Name: "b", Age: 40, Email: "A@example.com"})
+	if !errors.Is(err, comfyent.ErrEmailTaken) {
+		t.Fatalf("got %v, want ErrEmailTaken", err)
+	}
+}
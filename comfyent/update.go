@@ -0,0 +1,41 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	entschema "github.com/davidroman0O/comfylite3-ent/ent/schema"
+)
+
+// UpdateUserIfChanged sets the fields of the user with the given ID that differ from in, and
+// reports whether any did. When none does, no UPDATE is issued at all, so neither updated_at,
+// the version nor the audit log are touched.
+func UpdateUserIfChanged(ctx context.Context, client *ent.Client, id int, in UserInput) (bool, error) {
+	u, err := client.User.Get(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("loading user %d: %w", id, err)
+	}
+	upd := client.User.UpdateOneID(id)
+	changed := false
+	if in.Name != u.Name {
+		upd.SetName(in.Name)
+		changed = true
+	}
+	if in.Age != u.Age {
+		upd.SetAge(in.Age)
+		changed = true
+	}
+	// Emails are stored normalized, compare them the same way.
+	if email := entschema.NormalizeEmail(in.Email); email != u.Email {
+		upd.SetEmail(email)
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	if err := upd.Exec(ctx); err != nil {
+		return false, fmt.Errorf("updating user %d: %w", id, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,46 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestUpdateUserIfChanged(t *testing.T) {
+	client, log := newRecordingClient(t)
+	ctx := context.Background()
+	u := client.User.Create().SetName("alice").SetAge(30).SetEmail("alice@example.com").SaveX(ctx)
+
+	// The email differs in case only, it is the same once normalized.
+	n := log.Len()
+	changed, err := comfyent.UpdateUserIfChanged(ctx, client, u.ID, comfyent.UserInput{Name: "alice", Age: 30, Email: "Alice@Example.com"})
+	if err != nil {
+		t.Fatalf("UpdateUserIfChanged: %v", err)
+	}
+	if changed {
+		t.Fatal("got a change updating with identical values")
+	}
+	if stmts := log.Since(n, "UPDATE"); len(stmts) != 0 {
+		t.Fatalf("got UPDATE statements %q for identical values, want none", stmts)
+	}
+
+	n = log.Len()
+	changed, err = comfyent.UpdateUserIfChanged(ctx, client, u.ID, comfyent.UserInput{Name: "alice", Age: 31, Email: "alice@example.com"})
+	if err != nil {
+		t.Fatalf("UpdateUserIfChanged: %v", err)
+	}
+	if !changed {
+		t.Fatal("got no change updating the age")
+	}
+	if stmts := log.Since(n, "UPDATE"); len(stmts) != 1 {
+		t.Fatalf("got UPDATE statements %q, want one", stmts)
+	}
+	if got := client.User.GetX(ctx, u.ID); got.Age != 31 {
+		t.Fatalf("got age %d, want 31", got.Age)
+	}
+
+	if _, err := comfyent.UpdateUserIfChanged(ctx, client, u.ID+1, comfyent.UserInput{Name: "bob", Age: 30, Email: "bob@example.com"}); err == nil {
+		t.Fatal("UpdateUserIfChanged succeeded on a missing user")
+	}
+}
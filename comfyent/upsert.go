@@ -0,0 +1,66 @@
+package comfyent
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+// upsertBatchSize is the number of users sent per INSERT ... ON CONFLICT statement,
+// keeping well under SQLite's bound variables limit.
+const upsertBatchSize = 500
+
+// UserInput holds the fields to create or update a user with.
+type UserInput struct {
+	Name  string `json:"name"`
+	Age   int    `json:"age"`
+	Email string `json:"email"`
+}
+
+// builder returns a create builder for the user described by in.
+func (in UserInput) builder(client *ent.Client) *ent.UserCreate {
+	return client.User.Create().
+		SetName(in.Name).
+		SetAge(in.Age).
+		SetEmail(in.Email)
+}
+
+// UpsertUsers inserts the given users, or updates the name and age of the existing ones sharing
+// the same email, making syncs from external data idempotent. Updated users get their version
+// bumped; their other fields, such as role and active, are left alone. Up to upsertBatchSize
+// users are sent per statement.
+//
+// An existing user of another tenant than the one being upserted, see EnableTenancy, is
+// left untouched rather than taken over.
+func UpsertUsers(ctx context.Context, client *ent.Client, users []UserInput) error {
+	for start := 0; start < len(users); start += upsertBatchSize {
+		chunk := users[start:min(start+upsertBatchSize, len(users))]
+		builders := make([]*ent.UserCreate, len(chunk))
+		for i, in := range chunk {
+			builders[i] = in.builder(client)
+		}
+		err := client.User.CreateBulk(builders...).
+			OnConflict(
+				sql.ConflictColumns(user.FieldEmail),
+				sql.UpdateWhere(sameTenant),
+			).
+			Update(func(u *ent.UserUpsert) {
+				u.UpdateName().
+					UpdateAge().
+					UpdateUpdatedAt().
+					AddVersion(1)
+			}).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("upserting users: %w", err)
+		}
+	}
+	return nil
+}
+
+// sameTenant holds when the existing row conflicting with an upserted one belongs to the same
+// tenant, or both have none.
+var sameTenant = sql.ExprP(fmt.Sprintf("%s.%s IS excluded.%[2]s", quoteIdent(user.Table), quoteIdent(user.FieldTenantID)))
@@ -0,0 +1,72 @@
+package comfyent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestUpsertUsers(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	users := []comfyent.UserInput{
+		{Name: "a", Age: 20, Email: "a@example.com"},
+		{Name: "b", Age: 30, Email: "b@example.com"},
+		{Name: "c", Age: 40, Email: "c@example.com"},
+	}
+	if err := comfyent.UpsertUsers(ctx, client, users); err != nil {
+		t.Fatalf("UpsertUsers: %v", err)
+	}
+	client.User.Update().Where(user.Email("a@example.com")).SetRole(user.RoleAdmin).ExecX(ctx)
+	a := client.User.Query().Where(user.Email("a@example.com")).OnlyX(ctx)
+
+	for i := range users {
+		users[i].Age++
+	}
+	if err := comfyent.UpsertUsers(ctx, client, users); err != nil {
+		t.Fatalf("UpsertUsers again: %v", err)
+	}
+	if n := client.User.Query().CountX(ctx); n != 3 {
+		t.Fatalf("got %d users after upserting them again, want 3", n)
+	}
+	for _, in := range users {
+		u := client.User.Query().Where(user.Email(in.Email)).OnlyX(ctx)
+		if u.Age != in.Age {
+			t.Errorf("got %s aged %d, want %d", in.Email, u.Age, in.Age)
+		}
+	}
+	got := client.User.GetX(ctx, a.ID)
+	if got.Version != a.Version+1 {
+		t.Fatalf("got version %d after the upsert, want %d", got.Version, a.Version+1)
+	}
+	if got.Role != user.RoleAdmin {
+		t.Fatalf("got role %s after the upsert, want it left alone", got.Role)
+	}
+}
+
+func TestUpsertUsersOtherTenant(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	comfyent.EnableTenancy(client)
+	ctx := context.Background()
+	a, b := comfyent.WithTenant(ctx, "a"), comfyent.WithTenant(ctx, "b")
+
+	if err := comfyent.UpsertUsers(a, client, []comfyent.UserInput{{Name: "a", Age: 20, Email: "a@example.com"}}); err != nil {
+		t.Fatalf("UpsertUsers: %v", err)
+	}
+	if err := comfyent.UpsertUsers(b, client, []comfyent.UserInput{{Name: "b", Age: 99, Email: "a@example.com"}}); err != nil {
+		t.Fatalf("UpsertUsers as another tenant: %v", err)
+	}
+	u := client.User.Query().OnlyX(a)
+	if u.Name != "a" || u.Age != 20 {
+		t.Fatalf("got user %q aged %d, want the other tenant's upsert left out", u.Name, u.Age)
+	}
+	if n := client.User.Query().CountX(b); n != 0 {
+		t.Fatalf("got %d users for the other tenant, want 0", n)
+	}
+}
@@ -0,0 +1,58 @@
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrVacuumInTx is returned by Vacuum when a transaction is open on the connection.
+var ErrVacuumInTx = errors.New("VACUUM needs exclusive access and cannot run within a transaction")
+
+// Vacuum rebuilds the database file to give the pages freed by deletions back to the file
+// system, and returns how many bytes the database shrank by. In-memory databases are
+// left alone and report 0.
+//
+// VACUUM rewrites the whole file while holding an exclusive lock, so it blocks every other
+// statement until it's done; it fails with ErrVacuumInTx while a transaction is open.
+func Vacuum(ctx context.Context, db *sql.DB) (reclaimedBytes int64, err error) {
+	var (
+		seq        int
+		name, file string
+	)
+	if err := db.QueryRowContext(ctx, "PRAGMA database_list;").Scan(&seq, &name, &file); err != nil {
+		return 0, fmt.Errorf("locating database file: %w", err)
+	}
+	if file == "" {
+		return 0, nil
+	}
+	before, err := databaseSize(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := db.ExecContext(ctx, "VACUUM;"); err != nil {
+		if strings.Contains(err.Error(), "within a transaction") {
+			return 0, fmt.Errorf("%w: %w", ErrVacuumInTx, err)
+		}
+		return 0, fmt.Errorf("vacuuming database: %w", err)
+	}
+	after, err := databaseSize(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	return before - after, nil
+}
+
+// databaseSize returns the size of the main database in bytes, as SQLite accounts for it.
+func databaseSize(ctx context.Context, db *sql.DB) (int64, error) {
+	var pages, pageSize int64
+	if err := db.QueryRowContext(ctx, "PRAGMA page_count;").Scan(&pages); err != nil {
+		return 0, fmt.Errorf("reading page_count: %w", err)
+	}
+	if err := db.QueryRowContext(ctx, "PRAGMA page_size;").Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("reading page_size: %w", err)
+	}
+	return pages * pageSize, nil
+}
@@ -0,0 +1,40 @@
+package comfyent_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+func TestVacuum(t *testing.T) {
+	db := comfyent.OpenDB(newFileComfy(t))
+	defer db.Close()
+	ctx := context.Background()
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE blobs (b TEXT);"); err != nil {
+		t.Fatal(err)
+	}
+	blob := strings.Repeat("x", 4096)
+	for range 200 {
+		if _, err := db.ExecContext(ctx, "INSERT INTO blobs (b) VALUES (?);", blob); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, "DELETE FROM blobs;"); err != nil {
+		t.Fatal(err)
+	}
+
+	reclaimed, err := comfyent.Vacuum(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reclaimed < 200*4096 {
+		t.Fatalf("reclaimed %d bytes, want at least the %d deleted", reclaimed, 200*4096)
+	}
+	// Nothing is left to reclaim.
+	if reclaimed, err = comfyent.Vacuum(ctx, db); err != nil || reclaimed != 0 {
+		t.Fatalf("got %d, %v vacuuming again, want 0", reclaimed, err)
+	}
+}
@@ -0,0 +1,42 @@
+package comfyent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/davidroman0O/comfylite3-ent/ent"
+)
+
+// ValidationError reports the field a rejected value was given for, so it can be mapped back
+// to a form input.
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// AsValidationError finds the first validation error in err's chain, whether returned by an
+// ent builder (schema validators, missing required fields) or by a helper of this package.
+func AsValidationError(err error) (*ValidationError, bool) {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return verr, true
+	}
+	var entErr *ent.ValidationError
+	if !errors.As(err, &entErr) {
+		return nil, false
+	}
+	// ent wraps the validator error with the field it failed for: keep the validator's own message.
+	reason := errors.Unwrap(entErr)
+	if cause := errors.Unwrap(reason); cause != nil {
+		reason = cause
+	}
+	return &ValidationError{
+		Field:  entErr.Name,
+		Reason: strings.TrimPrefix(reason.Error(), "ent: "),
+	}, true
+}
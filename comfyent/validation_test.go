@@ -0,0 +1,36 @@
+package comfyent_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestAsValidationError(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	err := client.User.Create().SetName("").SetAge(30).SetEmail("a@example.com").Exec(ctx)
+	verr, ok := comfyent.AsValidationError(fmt.Errorf("signing up: %w", err))
+	if !ok {
+		t.Fatalf("got %v, want a validation error", err)
+	}
+	if verr.Field != "name" || verr.Reason == "" {
+		t.Fatalf("got field %q and reason %q, want the name with a reason", verr.Field, verr.Reason)
+	}
+
+	// Missing required fields are validation errors too.
+	err = client.User.Create().SetAge(30).SetEmail("a@example.com").Exec(ctx)
+	if verr, ok := comfyent.AsValidationError(err); !ok || verr.Field != "name" {
+		t.Fatalf("got %v, want a validation error on the name", err)
+	}
+
+	if _, ok := comfyent.AsValidationError(errors.New("boom")); ok {
+		t.Fatal("got a validation error out of an unrelated one")
+	}
+}
@@ -0,0 +1,80 @@
+// Package comfyent gathers helpers to open and operate comfylite3 databases used with ent.
+package comfyent
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/davidroman0O/comfylite3"
+)
+
+// OpenWithWAL opens the database like comfylite3.OpenDB and switches it to WAL journaling
+// with synchronous=NORMAL, which greatly improves concurrent reads.
+// It reads journal_mode back and fails if WAL didn't take effect (e.g. for in-memory databases).
+func OpenWithWAL(comfy *comfylite3.ComfyDB, opts ...comfylite3.OpenDBOption) (*sql.DB, error) {
+	db := comfylite3.OpenDB(comfy, opts...)
+	if _, err := db.Exec("PRAGMA journal_mode=WAL;"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("setting journal_mode: %w", err)
+	}
+	if _, err := db.Exec("PRAGMA synchronous=NORMAL;"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("setting synchronous: %w", err)
+	}
+	var mode string
+	if err := db.QueryRow("PRAGMA journal_mode;").Scan(&mode); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("reading journal_mode: %w", err)
+	}
+	if !strings.EqualFold(mode, "wal") {
+		db.Close()
+		return nil, fmt.Errorf("journal_mode is %q, expected wal", mode)
+	}
+	return db, nil
+}
+
+// StartWALCheckpointer runs PRAGMA wal_checkpoint(TRUNCATE) on db every interval, so the -wal
+// file is folded back into the database and truncated even when readers never leave it idle.
+// Failed or incomplete checkpoints are logged and retried at the next tick.
+//
+// It runs until ctx is done or stop is called; stop waits for a running checkpoint to finish
+// and can be called more than once. It fails if interval isn't positive.
+func StartWALCheckpointer(ctx context.Context, db *sql.DB, interval time.Duration) (stop func(), err error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("checkpoint interval must be positive, got %v", interval)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			var busy, logFrames, checkpointed int
+			err := db.QueryRowContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE);").Scan(&busy, &logFrames, &checkpointed)
+			switch {
+			case err != nil && ctx.Err() == nil:
+				log.Printf("comfyent: WAL checkpoint failed: %v", err)
+			case err == nil && busy != 0:
+				log.Printf("comfyent: WAL checkpoint blocked by another connection, %d of %d frames checkpointed", checkpointed, logFrames)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}, nil
+}
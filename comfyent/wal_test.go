@@ -0,0 +1,110 @@
+package comfyent_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+)
+
+// newFileComfy opens a ComfyDB on a fresh file of the test's temporary directory.
+func newFileComfy(t *testing.T) *comfylite3.ComfyDB {
+	t.Helper()
+	comfy, err := comfylite3.New(comfylite3.WithPath(filepath.Join(t.TempDir(), "test.db")))
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+	t.Cleanup(func() { comfy.Close() })
+	return comfy
+}
+
+func TestOpenWithWAL(t *testing.T) {
+	db, err := comfyent.OpenWithWAL(newFileComfy(t))
+	if err != nil {
+		t.Fatalf("OpenWithWAL: %v", err)
+	}
+	defer db.Close()
+
+	var mode string
+	if err := db.QueryRow("PRAGMA journal_mode;").Scan(&mode); err != nil {
+		t.Fatal(err)
+	}
+	if mode != "wal" {
+		t.Fatalf("got journal_mode %q, want wal", mode)
+	}
+	var synchronous int
+	if err := db.QueryRow("PRAGMA synchronous;").Scan(&synchronous); err != nil {
+		t.Fatal(err)
+	}
+	// NORMAL is 1.
+	if synchronous != 1 {
+		t.Fatalf("got synchronous %d, want 1", synchronous)
+	}
+}
+
+func TestOpenWithWALInMemory(t *testing.T) {
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+
+	if db, err := comfyent.OpenWithWAL(comfy); err == nil {
+		db.Close()
+		t.Fatal("OpenWithWAL succeeded on an in-memory database")
+	}
+}
+
+func TestStartWALCheckpointer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	comfy, err := comfylite3.New(comfylite3.WithPath(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	db, err := comfyent.OpenWithWAL(comfy)
+	if err != nil {
+		t.Fatalf("OpenWithWAL: %v", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	if _, err := comfyent.StartWALCheckpointer(ctx, db, 0); err == nil {
+		t.Fatal("StartWALCheckpointer succeeded with a zero interval")
+	}
+
+	if _, err := db.Exec("CREATE TABLE t (v TEXT);"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50; i++ {
+		if _, err := db.Exec("INSERT INTO t (v) VALUES (?);", strings.Repeat("x", 1000)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	walSize := func() int64 {
+		fi, err := os.Stat(path + "-wal")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fi.Size()
+	}
+	if walSize() == 0 {
+		t.Fatal("got an empty WAL after writes")
+	}
+
+	stop, err := comfyent.StartWALCheckpointer(ctx, db, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("StartWALCheckpointer: %v", err)
+	}
+	defer stop()
+	for deadline := time.Now().Add(5 * time.Second); walSize() != 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("WAL still holds %d bytes, want it truncated", walSize())
+		}
+	}
+}
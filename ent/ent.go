@@ -12,6 +12,10 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/davidroman0O/comfylite3-ent/ent/address"
+	"github.com/davidroman0O/comfylite3-ent/ent/auditlog"
+	"github.com/davidroman0O/comfylite3-ent/ent/idempotencykey"
+	"github.com/davidroman0O/comfylite3-ent/ent/phone"
 	"github.com/davidroman0O/comfylite3-ent/ent/user"
 )
 
@@ -73,7 +77,11 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			user.Table: user.ValidColumn,
+			address.Table:        address.ValidColumn,
+			auditlog.Table:       auditlog.ValidColumn,
+			idempotencykey.Table: idempotencykey.ValidColumn,
+			phone.Table:          phone.ValidColumn,
+			user.Table:           user.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
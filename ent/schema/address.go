@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Address holds the schema definition for the Address entity, the primary address of a User.
+type Address struct {
+	ent.Schema
+}
+
+// Fields of the Address.
+func (Address) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("street").
+			NotEmpty(),
+		field.String("city").
+			NotEmpty(),
+		field.String("postal_code").
+			NotEmpty(),
+		field.String("country").
+			NotEmpty(),
+	}
+}
+
+// Edges of the Address.
+func (Address) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("address").
+			Unique(),
+	}
+}
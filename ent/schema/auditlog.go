@@ -0,0 +1,281 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	gen "github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/auditlog"
+	"github.com/davidroman0O/comfylite3-ent/ent/hook"
+	"github.com/davidroman0O/comfylite3-ent/ent/schematype"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+	"github.com/davidroman0O/comfylite3-ent/internal/implicittx"
+)
+
+// AuditLog holds the schema definition for the AuditLog entity, one row per entity mutated.
+// Rows are written by hooks and never updated.
+type AuditLog struct {
+	ent.Schema
+}
+
+// Fields of the AuditLog.
+func (AuditLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("entity_type").
+			NotEmpty().
+			Immutable(),
+		field.Int("entity_id").
+			Immutable(),
+		field.Enum("operation").
+			Values("create", "update", "delete").
+			Immutable(),
+		field.JSON("changes", map[string]schematype.AuditChange{}).
+			Immutable(),
+		// actor is who performed the mutation, see WithActor.
+		field.String("actor").
+			Default(systemActor).
+			Immutable(),
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now),
+	}
+}
+
+// Indexes of the AuditLog.
+func (AuditLog) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("entity_type", "entity_id"),
+	}
+}
+
+// systemActor is the actor of mutations run without one, e.g. by jobs or migrations.
+const systemActor = "system"
+
+type actorKey struct{}
+
+// WithActor returns a context whose mutations are recorded in the audit log as performed by actorID.
+func WithActor(parent context.Context, actorID string) context.Context {
+	return context.WithValue(parent, actorKey{}, actorID)
+}
+
+// ActorFromContext returns the actor set by WithActor, if any.
+func ActorFromContext(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(actorKey{}).(string)
+	return actor, ok && actor != ""
+}
+
+// auditUser writes an AuditLog row per user mutated, with the before and after values of the
+// mutated fields. Mutations run within a transaction write their rows within it. Those run
+// outside of one get an implicit transaction for the mutation and its rows on clients on a
+// driver.ImplicitTxDriver, as the clients of comfyent are. Other clients write the rows right
+// after the mutation, which then isn't rolled back if writing them fails. Creates conflicting
+// with the user holding their email, which only succeed as upserts, are audited as updates of
+// that user: creates read back the user they wrote to tell. The values of encrypted fields are
+// redacted from the changes.
+//
+// A bulk create chains the mutations of its builders with the context it was saved with, so
+// outside of a transaction each builder gets its own implicit transaction, and only the last
+// one's row is written within the batch's. Run bulk creates within a transaction for all of
+// their rows to be.
+func auditUser(next ent.Mutator) ent.Mutator {
+	return hook.UserFunc(func(ctx context.Context, m *gen.UserMutation) (gen.Value, error) {
+		_, implicit := implicittx.From(ctx)
+		if _, err := m.Tx(); err == nil || implicit {
+			return auditMutation(ctx, next, m)
+		}
+		ctx, itx := implicittx.With(ctx)
+		v, err := auditMutation(ctx, next, m)
+		if err != nil {
+			itx.Rollback()
+			return nil, err
+		}
+		if err := itx.Commit(); err != nil {
+			return nil, fmt.Errorf("committing audited user mutation: %w", err)
+		}
+		return v, nil
+	})
+}
+
+// auditMutation runs m and writes its AuditLog rows with the mutation's client.
+func auditMutation(ctx context.Context, next ent.Mutator, m *gen.UserMutation) (gen.Value, error) {
+	operation := auditlog.OperationUpdate
+	switch {
+	case m.Op().Is(ent.OpCreate):
+		operation = auditlog.OperationCreate
+	case m.Op().Is(ent.OpDelete | ent.OpDeleteOne):
+		operation = auditlog.OperationDelete
+	default:
+		// Soft deletes reach hooks as updates setting deleted_at.
+		if _, ok := m.DeletedAt(); ok {
+			operation = auditlog.OperationDelete
+		}
+	}
+	var before map[int]map[string]any
+	if operation != auditlog.OperationCreate {
+		ids, err := m.IDs(SkipSoftDelete(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("auditing user mutation: %w", err)
+		}
+		if before, err = snapshotUsers(ctx, m.Client(), ids); err != nil {
+			return nil, fmt.Errorf("auditing user mutation: %w", err)
+		}
+	}
+	v, err := next.Mutate(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	var upserted map[string]any
+	if u, ok := v.(*gen.User); ok && operation == auditlog.OperationCreate {
+		before = map[int]map[string]any{u.ID: nil}
+		if upserted, err = upsertedUser(ctx, m, u.ID); err != nil {
+			return nil, fmt.Errorf("auditing user mutation: %w", err)
+		}
+		if upserted != nil {
+			operation = auditlog.OperationUpdate
+		}
+	}
+	actor, ok := ActorFromContext(ctx)
+	if !ok {
+		actor = systemActor
+	}
+	rows := make([]*gen.AuditLogCreate, 0, len(before))
+	for id, old := range before {
+		changes := make(map[string]schematype.AuditChange)
+		if upserted != nil {
+			// The values the user had before the upsert are gone: only the fields now holding
+			// the values the create gave, which the upsert may have set, are recorded.
+			for _, name := range m.Fields() {
+				value, _ := m.Field(name)
+				if sameValue(value, upserted[name]) {
+					changes[name] = schematype.AuditChange{New: upserted[name]}
+				}
+			}
+			rows = append(rows, auditRow(m.Client(), id, operation, changes, actor))
+			continue
+		}
+		if m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+			for name, value := range old {
+				changes[name] = schematype.AuditChange{Old: value}
+			}
+		}
+		for _, name := range m.Fields() {
+			value, _ := m.Field(name)
+			changes[name] = schematype.AuditChange{Old: old[name], New: value}
+		}
+		for _, name := range m.ClearedFields() {
+			changes[name] = schematype.AuditChange{Old: old[name]}
+		}
+		rows = append(rows, auditRow(m.Client(), id, operation, changes, actor))
+	}
+	if len(rows) == 0 {
+		return v, nil
+	}
+	if err := m.Client().AuditLog.CreateBulk(rows...).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("writing audit log: %w", err)
+	}
+	return v, nil
+}
+
+// redactedFields are the fields holding EncryptedString values, which the audit log doesn't copy.
+var redactedFields = map[string]bool{
+	user.FieldEmail: true,
+}
+
+// redactedValue stands for the values of redactedFields in the audit log.
+const redactedValue = "[redacted]"
+
+// auditRow returns the builder of the AuditLog row of the user id.
+func auditRow(client *gen.Client, id int, operation auditlog.Operation, changes map[string]schematype.AuditChange, actor string) *gen.AuditLogCreate {
+	for name, change := range changes {
+		if !redactedFields[name] {
+			continue
+		}
+		// Only whether the value was set or changed is kept.
+		if change.Old != nil {
+			change.Old = redactedValue
+		}
+		if change.New != nil {
+			change.New = redactedValue
+		}
+		changes[name] = change
+	}
+	return client.AuditLog.Create().
+		SetEntityType(user.Label).
+		SetEntityID(id).
+		SetOperation(operation).
+		SetChanges(changes).
+		SetActor(actor)
+}
+
+// upsertedUser returns the field values of the user id created by m if it already existed, the
+// create having been resolved as an upsert updating it, and nil otherwise. Users are told apart
+// by their creation time, which upserts leave alone.
+func upsertedUser(ctx context.Context, m *gen.UserMutation, id int) (map[string]any, error) {
+	createdAt, ok := m.CreatedAt()
+	if !ok {
+		return nil, nil
+	}
+	snapshots, err := snapshotUsers(ctx, m.Client(), []int{id})
+	if err != nil {
+		return nil, err
+	}
+	fields := snapshots[id]
+	stored, ok := fields[user.FieldCreatedAt].(string)
+	if !ok {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, stored)
+	if err != nil || t.Equal(createdAt) {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// sameValue reports whether the value of a mutation field is the value snapshotUsers got.
+func sameValue(value, snapshot any) bool {
+	if t, ok := value.(time.Time); ok {
+		s, _ := snapshot.(string)
+		st, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil && st.Equal(t)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(v, snapshot)
+}
+
+// snapshotUsers returns the field values of the given users, keyed by ID then field name.
+func snapshotUsers(ctx context.Context, client *gen.Client, ids []int) (map[int]map[string]any, error) {
+	users, err := client.User.Query().Where(user.IDIn(ids...)).All(SkipSoftDelete(ctx))
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make(map[int]map[string]any, len(users))
+	for _, u := range users {
+		// The JSON form of an entity is keyed by field name.
+		data, err := json.Marshal(u)
+		if err != nil {
+			return nil, err
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+		delete(fields, "id")
+		delete(fields, "edges")
+		snapshots[u.ID] = fields
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,174 @@
+package schema_test
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/auditlog"
+	"github.com/davidroman0O/comfylite3-ent/ent/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestAuditUserUpdate(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	client.User.UpdateOne(u).SetAge(31).ExecX(ctx)
+
+	row := client.AuditLog.Query().
+		Where(auditlog.EntityType(user.Label), auditlog.EntityID(u.ID), auditlog.OperationEQ(auditlog.OperationUpdate)).
+		OnlyX(ctx)
+	age, ok := row.Changes["age"]
+	if !ok {
+		t.Fatalf("got changes %v, want the age", row.Changes)
+	}
+	// Numbers come back from the JSON column as float64.
+	if age.Old != float64(30) || age.New != float64(31) {
+		t.Fatalf("got age change %v -> %v, want 30 -> 31", age.Old, age.New)
+	}
+	if n := client.AuditLog.Query().Where(auditlog.EntityID(u.ID)).CountX(ctx); n != 2 {
+		t.Fatalf("got %d audit rows, want one for the create and one for the update", n)
+	}
+}
+
+// The clients of the readme aren't on a driver.ImplicitTxDriver, their mutations are audited all the same.
+func TestAuditUserPlainClient(t *testing.T) {
+	comfy, err := comfylite3.New(comfylite3.WithMemory())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.SQLite, comfyent.OpenDB(comfy, comfylite3.WithForeignKeys()))))
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	client.User.UpdateOne(u).SetAge(31).ExecX(ctx)
+	client.User.DeleteOne(u).ExecX(ctx)
+
+	ops := client.AuditLog.Query().
+		Where(auditlog.EntityID(u.ID)).
+		Order(auditlog.ByID()).
+		Select(auditlog.FieldOperation).
+		StringsX(ctx)
+	if len(ops) != 3 || ops[0] != "create" || ops[1] != "update" || ops[2] != "delete" {
+		t.Fatalf("got audited operations %v, want create, update and delete", ops)
+	}
+}
+
+func TestAuditActor(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	client.User.UpdateOne(u).SetAge(31).ExecX(schema.WithActor(ctx, "admin-7"))
+
+	actors := client.AuditLog.Query().
+		Where(auditlog.EntityID(u.ID)).
+		Order(auditlog.ByID()).
+		Select(auditlog.FieldActor).
+		StringsX(ctx)
+	if len(actors) != 2 || actors[0] != "system" || actors[1] != "admin-7" {
+		t.Fatalf("got actors %q, want system for the create and admin-7 for the update", actors)
+	}
+}
+
+func TestAuditUserUpsert(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	err := client.User.Create().SetName("a").SetAge(31).SetEmail("a@example.com").
+		OnConflictColumns(user.FieldEmail).
+		Update(func(u *ent.UserUpsert) { u.UpdateAge() }).
+		Exec(ctx)
+	if err != nil {
+		t.Fatalf("upserting: %v", err)
+	}
+
+	rows := client.AuditLog.Query().Where(auditlog.EntityID(u.ID)).Order(auditlog.ByID()).AllX(ctx)
+	if len(rows) != 2 || rows[0].Operation != auditlog.OperationCreate || rows[1].Operation != auditlog.OperationUpdate {
+		t.Fatalf("got %d audit rows %v, want the create then an update", len(rows), rows)
+	}
+	changes := rows[1].Changes
+	if age, ok := changes["age"]; !ok || age.New != float64(31) {
+		t.Fatalf("got changes %v, want the age set to 31", changes)
+	}
+	// The creation time the upsert left alone isn't reported as changed.
+	if _, ok := changes["created_at"]; ok {
+		t.Fatalf("got changes %v, want the creation time left out", changes)
+	}
+}
+
+func TestAuditUserBulkUpsert(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	err := client.User.CreateBulk(
+		client.User.Create().SetName("a").SetAge(31).SetEmail("a@example.com"),
+		client.User.Create().SetName("b").SetAge(40).SetEmail("b@example.com"),
+	).
+		OnConflictColumns(user.FieldEmail).
+		Update(func(u *ent.UserUpsert) { u.UpdateAge() }).
+		Exec(ctx)
+	if err != nil {
+		t.Fatalf("upserting: %v", err)
+	}
+
+	ops := client.AuditLog.Query().
+		Where(auditlog.EntityID(u.ID)).
+		Order(auditlog.ByID()).
+		Select(auditlog.FieldOperation).
+		StringsX(ctx)
+	if len(ops) != 2 || ops[0] != "create" || ops[1] != "update" {
+		t.Fatalf("got audited operations %v for the existing user, want create and update", ops)
+	}
+	b := client.User.Query().Where(user.Email("b@example.com")).OnlyX(ctx)
+	if n := client.AuditLog.Query().Where(auditlog.EntityID(b.ID), auditlog.OperationEQ(auditlog.OperationCreate)).CountX(ctx); n != 1 {
+		t.Fatalf("got %d creates audited for the new user, want 1", n)
+	}
+}
+
+// Emails are encrypted fields, the audit log doesn't copy them.
+func TestAuditUserRedactsEmail(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	client.User.UpdateOne(u).SetEmail("b@example.com").ExecX(ctx)
+
+	rows := client.AuditLog.Query().Where(auditlog.EntityID(u.ID)).Order(auditlog.ByID()).AllX(ctx)
+	if len(rows) != 2 {
+		t.Fatalf("got %d audit rows, want 2", len(rows))
+	}
+	for _, row := range rows {
+		email, ok := row.Changes["email"]
+		if !ok {
+			t.Fatalf("got %s changes %v, want the email", row.Operation, row.Changes)
+		}
+		for _, v := range []any{email.Old, email.New} {
+			if v != nil && v != "[redacted]" {
+				t.Fatalf("got %s email change %v -> %v, want it redacted", row.Operation, email.Old, email.New)
+			}
+		}
+	}
+	if email := rows[1].Changes["email"]; email.Old == nil || email.New == nil {
+		t.Fatalf("got update email change %v -> %v, want both values set", email.Old, email.New)
+	}
+}
@@ -0,0 +1,30 @@
+package schema_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+)
+
+func TestTimeMixin(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	before := time.Now()
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	if u.CreatedAt.Before(before) || u.UpdatedAt.Before(before) {
+		t.Fatalf("got created_at %v and updated_at %v, want both after %v", u.CreatedAt, u.UpdatedAt, before)
+	}
+
+	time.Sleep(time.Millisecond)
+	updated := client.User.UpdateOne(u).SetAge(31).SaveX(ctx)
+	if !updated.UpdatedAt.After(u.UpdatedAt) {
+		t.Fatalf("got updated_at %v after the update, want after %v", updated.UpdatedAt, u.UpdatedAt)
+	}
+	if got := client.User.GetX(ctx, u.ID); !got.CreatedAt.Equal(u.CreatedAt) {
+		t.Fatalf("got created_at %v after the update, want %v", got.CreatedAt, u.CreatedAt)
+	}
+}
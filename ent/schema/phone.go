@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+
+	"github.com/davidroman0O/comfylite3-ent/ent/schematype"
+)
+
+// Phone holds the schema definition for the Phone entity.
+type Phone struct {
+	ent.Schema
+}
+
+// Fields of the Phone.
+func (Phone) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("number").
+			NotEmpty(),
+		// "label" would generate a phone.Label type clashing with the entity label constant,
+		// so the enum is backed by a named type instead.
+		field.Enum("label").
+			GoType(schematype.PhoneLabel("")),
+	}
+}
+
+// Edges of the Phone.
+func (Phone) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("phones").
+			Unique(),
+	}
+}
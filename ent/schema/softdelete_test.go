@@ -0,0 +1,48 @@
+package schema_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestSoftDelete(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	a := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	client.User.Create().SetName("b").SetAge(30).SetEmail("b@example.com").ExecX(ctx)
+
+	client.User.DeleteOne(a).ExecX(ctx)
+	if n := client.User.Query().CountX(ctx); n != 1 {
+		t.Fatalf("got %d users after the soft delete, want 1", n)
+	}
+	deleted := client.User.Query().Where(user.ID(a.ID)).OnlyX(schema.SkipSoftDelete(ctx))
+	if deleted.DeletedAt == nil {
+		t.Fatal("soft-deleted user has no deleted_at")
+	}
+
+	// Bulk deletes are soft too, and leave soft-deleted rows alone.
+	if n := client.User.Delete().ExecX(ctx); n != 1 {
+		t.Fatalf("deleted %d users, want 1", n)
+	}
+	if n := client.User.Query().CountX(schema.SkipSoftDelete(ctx)); n != 2 {
+		t.Fatalf("got %d rows after soft deletes, want 2", n)
+	}
+}
+
+func TestSkipSoftDeleteDeletesPermanently(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := schema.SkipSoftDelete(context.Background())
+
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("a@example.com").SaveX(ctx)
+	client.User.DeleteOne(u).ExecX(ctx)
+	if n := client.User.Query().CountX(ctx); n != 0 {
+		t.Fatalf("got %d rows after a permanent delete, want 0", n)
+	}
+}
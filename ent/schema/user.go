@@ -1,8 +1,19 @@
 package schema
 
 import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
 	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	gen "github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/hook"
+	"github.com/davidroman0O/comfylite3-ent/ent/schematype"
 )
 
 // User holds the schema definition for the User entity.
@@ -10,20 +21,119 @@ type User struct {
 	ent.Schema
 }
 
+// Mixin of the User.
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		SoftDeleteMixin{},
+	}
+}
+
 // Fields of the User.
 func (User) Fields() []ent.Field {
 	return []ent.Field{
 		field.String("name").
 			NotEmpty(),
 		field.Int("age").
-			Positive(),
+			Validate(validateAge).
+			Positive().
+			Max(MaxAge),
+		// email is encrypted by the clients holding a key, see schematype.EncryptedString.
 		field.String("email").
 			NotEmpty().
-			Unique(),
+			Unique().
+			Validate(validateEmail).
+			ValueScanner(schematype.EncryptedString{}),
+		field.Enum("role").
+			Values("admin", "member", "guest").
+			Default("member"),
+		// version is bumped by every update, see bumpVersion.
+		field.Int("version").
+			Default(1),
+		// metadata holds app-specific attributes that don't deserve their own column.
+		field.JSON("metadata", map[string]any{}).
+			Optional(),
+		// active is cleared to disable an account while keeping it visible, unlike soft deletes.
+		field.Bool("active").
+			Default(true),
+		// tenant_id is stamped and filtered on by comfyent.EnableTenancy.
+		field.String("tenant_id").
+			Optional().
+			Immutable(),
 	}
 }
 
 // Edges of the User.
 func (User) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("phones", Phone.Type),
+		edge.To("address", Address.Type).
+			Unique(),
+		// A M2M edge to the same type without an inverse is symmetric:
+		// adding Bob to Alice's friends also makes Alice one of Bob's.
+		edge.To("friends", User.Type),
+	}
+}
+
+// Indexes of the User.
+func (User) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("active"),
+		index.Fields("tenant_id"),
+	}
+}
+
+// MaxAge is the highest age considered realistic for a User.
+const MaxAge = 150
+
+// validateAge guards against data-entry bugs. It is registered before Positive and Max so
+// callers get this message rather than ent's generic "value out of range".
+func validateAge(age int) error {
+	if age < 1 || age > MaxAge {
+		return fmt.Errorf("age must be between 1 and %d", MaxAge)
+	}
 	return nil
 }
+
+// validateEmail rejects anything that isn't a bare email address.
+func validateEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return fmt.Errorf("%q is not a valid email address", email)
+	}
+	return nil
+}
+
+// Hooks of the User.
+func (User) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(normalizeEmail, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne),
+		hook.On(bumpVersion, ent.OpUpdate|ent.OpUpdateOne),
+		auditUser,
+	}
+}
+
+// bumpVersion increments the version of the updated users, which lets writers detect
+// that a row changed since they read it.
+func bumpVersion(next ent.Mutator) ent.Mutator {
+	return hook.UserFunc(func(ctx context.Context, m *gen.UserMutation) (gen.Value, error) {
+		m.AddVersion(1)
+		return next.Mutate(ctx, m)
+	})
+}
+
+// NormalizeEmail returns email as it is stored, for lookups to match what normalizeEmail saved.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizeEmail trims and lowercases the email being set, so the unique index
+// treats addresses differing only by case as duplicates.
+func normalizeEmail(next ent.Mutator) ent.Mutator {
+	return hook.UserFunc(func(ctx context.Context, m *gen.UserMutation) (gen.Value, error) {
+		if email, ok := m.Email(); ok {
+			m.SetEmail(NormalizeEmail(email))
+		}
+		return next.Mutate(ctx, m)
+	})
+}
@@ -0,0 +1,93 @@
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent/comfyenttest"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/predicate"
+	"github.com/davidroman0O/comfylite3-ent/ent/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("  Alice@Example.COM ").SaveX(ctx)
+	if u.Email != "alice@example.com" {
+		t.Fatalf("got email %q on create, want alice@example.com", u.Email)
+	}
+	u = client.User.UpdateOne(u).SetEmail("ALICE@example.org").SaveX(ctx)
+	if u.Email != "alice@example.org" {
+		t.Fatalf("got email %q on update, want alice@example.org", u.Email)
+	}
+	client.User.Update().Where(user.ID(u.ID)).SetEmail(" Bob@Example.org").ExecX(ctx)
+	if got := client.User.GetX(ctx, u.ID).Email; got != "bob@example.org" {
+		t.Fatalf("got email %q on bulk update, want bob@example.org", got)
+	}
+	// Addresses differing only by case collide on the unique index.
+	if err := client.User.Create().SetName("b").SetAge(30).SetEmail("BOB@example.org").Exec(ctx); err == nil {
+		t.Fatal("created a user whose email differs only by case from another")
+	}
+}
+
+func TestValidateEmail(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, email := range []string{"not-an-email", "Alice <alice@example.com>", "alice@", "a@example.com, b@example.com"} {
+		err := client.User.Create().SetName("a").SetAge(30).SetEmail(email).Exec(ctx)
+		if !ent.IsValidationError(err) {
+			t.Errorf("creating a user with email %q: got error %v, want a validation error", email, err)
+		}
+	}
+	if err := client.User.Create().SetName("a").SetAge(30).SetEmail("alice@example.com").Exec(ctx); err != nil {
+		t.Fatalf("creating a user with a valid email: %v", err)
+	}
+}
+
+func TestValidateAge(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, age := range []int{0, -1, schema.MaxAge + 1} {
+		err := client.User.Create().SetName("a").SetAge(age).SetEmail("a@example.com").Exec(ctx)
+		if !ent.IsValidationError(err) || !strings.Contains(err.Error(), "age must be between 1 and 150") {
+			t.Errorf("creating a user aged %d: got error %v, want the age range", age, err)
+		}
+	}
+	for i, age := range []int{1, schema.MaxAge} {
+		email := fmt.Sprintf("user%d@example.com", i)
+		if err := client.User.Create().SetName("a").SetAge(age).SetEmail(email).Exec(ctx); err != nil {
+			t.Errorf("creating a user aged %d: %v", age, err)
+		}
+	}
+}
+
+// Without an encryption key, emails are plain strings that every predicate takes.
+func TestEmailPredicatesWithoutKey(t *testing.T) {
+	client, cleanup := comfyenttest.NewTestClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	client.User.Create().SetName("a").SetAge(30).SetEmail("alice@example.com").ExecX(ctx)
+
+	for name, p := range map[string]predicate.User{
+		"Contains":     user.EmailContains("ice@"),
+		"HasPrefix":    user.EmailHasPrefix("alice"),
+		"HasSuffix":    user.EmailHasSuffix("example.com"),
+		"ContainsFold": user.EmailContainsFold("ALICE"),
+		"EqualFold":    user.EmailEqualFold("Alice@Example.com"),
+	} {
+		n, err := client.User.Query().Where(p).Count(ctx)
+		if err != nil || n != 1 {
+			t.Errorf("Email%s: got %d users and error %v, want 1 user", name, n, err)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package schematype
+
+// AuditChange holds the values of a field before and after a mutation.
+// Old is nil for created entities, New is nil for deleted ones and cleared fields.
+type AuditChange struct {
+	Old any `json:"old,omitempty"`
+	New any `json:"new,omitempty"`
+}
@@ -0,0 +1,112 @@
+package schematype
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/schema/field"
+)
+
+// encryptedPrefix marks encrypted values, telling them apart from plaintext written without
+// a key.
+const encryptedPrefix = "enc:"
+
+// ErrNoEncryptionKey is returned when reading an encrypted value on a client without its key.
+var ErrNoEncryptionKey = errors.New("no encryption key for the value")
+
+// Cipher encrypts and decrypts the values of EncryptedString fields with an AES-GCM key.
+type Cipher struct {
+	aead cipher.AEAD
+	mac  []byte
+}
+
+// NewCipher returns a Cipher for k, an AES key 16, 24 or 32 bytes long.
+func NewCipher(k []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	// The nonces are derived from the plaintext with a key of their own.
+	mac := hmac.New(sha256.New, k)
+	mac.Write([]byte("comfyent nonce key"))
+	return &Cipher{aead: aead, mac: mac.Sum(nil)}, nil
+}
+
+// Encrypt returns the ciphertext of v.
+func (c *Cipher) Encrypt(v string) string {
+	mac := hmac.New(sha256.New, c.mac)
+	mac.Write([]byte(v))
+	nonce := mac.Sum(nil)[:c.aead.NonceSize()]
+	// Hex, unlike base64, is left alone by lower().
+	return encryptedPrefix + hex.EncodeToString(c.aead.Seal(nonce, nonce, []byte(v), nil))
+}
+
+// Decrypt returns the plaintext of s, or false if s isn't a value encrypted with c.
+func (c *Cipher) Decrypt(s string) (string, bool) {
+	ct, ok := strings.CutPrefix(s, encryptedPrefix)
+	if !ok {
+		return "", false
+	}
+	b, err := hex.DecodeString(ct)
+	if err != nil || len(b) < c.aead.NonceSize() {
+		return "", false
+	}
+	n := c.aead.NonceSize()
+	pt, err := c.aead.Open(nil, b[:n], b[n:], nil)
+	if err != nil {
+		return "", false
+	}
+	return string(pt), true
+}
+
+// EncryptedString is the ValueScanner of string fields encrypted with AES-GCM:
+//
+//	field.String("email").ValueScanner(schematype.EncryptedString{})
+//
+// The key belongs to the client: values go to the database as plain strings, which the
+// connections of a client opened with comfyent.Config.EncryptionKey encrypt when they are bound
+// to the column, and decrypt when reading them back. Clients without one write and read
+// plaintext, and fail with ErrNoEncryptionKey on encrypted values.
+//
+// Encryption is deterministic, the nonce being derived from the value, so equal values have
+// equal ciphertexts: unique indexes and equality predicates keep working, at the cost of
+// revealing which rows share a value. Predicates looking inside the value, such as Contains,
+// HasPrefix or EqualFold, compare it with the ciphertexts and match nothing on the clients
+// with a key. SQL sees the ciphertexts: functions such as lower(), indexes on expressions and
+// FTS5 indexes don't see the values. Only the column is encrypted, the audit log leaves
+// the value out of its changes.
+type EncryptedString struct{}
+
+// Value returns v as is, for the connection to encrypt.
+func (EncryptedString) Value(v string) (driver.Value, error) {
+	return v, nil
+}
+
+// ScanValue returns the value scanned from the database.
+func (EncryptedString) ScanValue() field.ValueScanner {
+	return &sql.NullString{}
+}
+
+// FromValue returns the value scanned, which the connection decrypted if it could.
+func (EncryptedString) FromValue(v driver.Value) (string, error) {
+	s, ok := v.(*sql.NullString)
+	if !ok {
+		return "", fmt.Errorf("unexpected input for FromValue: %T", v)
+	}
+	if strings.HasPrefix(s.String, encryptedPrefix) {
+		return "", ErrNoEncryptionKey
+	}
+	return s.String, nil
+}
@@ -0,0 +1,104 @@
+package schematype_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/ent"
+	_ "github.com/davidroman0O/comfylite3-ent/ent/runtime"
+	"github.com/davidroman0O/comfylite3-ent/ent/user"
+)
+
+func TestEncryptedString(t *testing.T) {
+	client, comfy, err := comfyent.Open(comfyent.Config{
+		InMemory:      true,
+		ForeignKeys:   true,
+		EncryptionKey: []byte("0123456789abcdef0123456789abcdef"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	u := client.User.Create().SetName("a").SetAge(30).SetEmail("alice@example.com").SaveX(ctx)
+	got := client.User.Query().Where(user.Email("alice@example.com")).OnlyX(ctx)
+	if got.ID != u.ID || got.Email != "alice@example.com" {
+		t.Fatalf("got user %d with email %q, want user %d with alice@example.com", got.ID, got.Email, u.ID)
+	}
+	// Columns other than the email are left alone.
+	if got.Name != "a" {
+		t.Fatalf("got name %q, want a", got.Name)
+	}
+
+	var raw []byte
+	if err := comfy.QueryRowContext(ctx, "SELECT email FROM users WHERE id = ?", u.ID).Scan(&raw); err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) == 0 || strings.Contains(string(raw), "alice") {
+		t.Fatalf("got raw email %q, want a ciphertext", raw)
+	}
+}
+
+// A client with a key leaves the email predicates of the clients without one alone.
+func TestEncryptedStringPerClient(t *testing.T) {
+	ctx := context.Background()
+	open := func(key []byte) *ent.Client {
+		client, comfy, err := comfyent.Open(comfyent.Config{InMemory: true, ForeignKeys: true, EncryptionKey: key})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			client.Close()
+			comfy.Close()
+		})
+		if err := client.Schema.Create(ctx); err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+	keyed := open([]byte("0123456789abcdef0123456789abcdef"))
+	plain := open(nil)
+
+	for _, client := range []*ent.Client{keyed, plain} {
+		client.User.CreateBulk(
+			client.User.Create().SetName("a").SetAge(30).SetEmail("a@test.com"),
+			client.User.Create().SetName("b").SetAge(30).SetEmail("b@example.com"),
+			client.User.Create().SetName("c").SetAge(30).SetEmail("c@test.com"),
+		).ExecX(ctx)
+	}
+
+	if n, err := plain.User.Query().Where(user.EmailHasSuffix("@test.com")).Count(ctx); err != nil || n != 2 {
+		t.Fatalf("EmailHasSuffix without a key: got %d users and error %v, want 2 users", n, err)
+	}
+	res, err := comfyent.DeleteUsersWhere(ctx, plain, comfyent.DeleteOptions{}, user.EmailHasSuffix("@test.com"))
+	if err != nil || res.Count != 2 {
+		t.Fatalf("DeleteUsersWhere without a key: got %+v and error %v, want 2 users", res, err)
+	}
+	left := plain.User.Query().OnlyX(ctx)
+	if left.Email != "b@example.com" {
+		t.Fatalf("got %s left, want b@example.com", left.Email)
+	}
+
+	// The keyed client encrypts the values compared with the column, not the others.
+	if n := keyed.User.Query().Where(user.EmailIn("a@test.com", "c@test.com")).CountX(ctx); n != 2 {
+		t.Fatalf("EmailIn with a key: got %d users, want 2", n)
+	}
+	if n := keyed.User.Query().Where(user.EmailNEQ("a@test.com")).CountX(ctx); n != 2 {
+		t.Fatalf("EmailNEQ with a key: got %d users, want 2", n)
+	}
+	if n := keyed.User.Query().Where(user.EmailHasSuffix("@test.com")).CountX(ctx); n != 0 {
+		t.Fatalf("EmailHasSuffix with a key: got %d users, want 0", n)
+	}
+	keyed.User.Update().Where(user.Email("b@example.com")).SetEmail("b@test.com").ExecX(ctx)
+	u := keyed.User.Query().Where(user.Email("b@test.com")).OnlyX(ctx)
+	if u.Name != "b" {
+		t.Fatalf("got user %s with b@test.com, want b", u.Name)
+	}
+}
@@ -0,0 +1,22 @@
+// Package schematype holds the Go types used by schema fields. It lives outside of
+// the schema package so generated entity packages can import it without a cycle.
+package schematype
+
+// PhoneLabel is the kind of a Phone number.
+type PhoneLabel string
+
+// Phone labels.
+const (
+	PhoneLabelHome   PhoneLabel = "home"
+	PhoneLabelWork   PhoneLabel = "work"
+	PhoneLabelMobile PhoneLabel = "mobile"
+)
+
+// Values lists the valid phone labels.
+func (PhoneLabel) Values() []string {
+	return []string{
+		string(PhoneLabelHome),
+		string(PhoneLabelWork),
+		string(PhoneLabelMobile),
+	}
+}
@@ -0,0 +1,16 @@
+package user
+
+import (
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3-ent/ent/predicate"
+)
+
+// NameMatchesRegexp applies the REGEXP predicate on the "name" field, with pattern in Go's
+// regexp syntax. It requires the functions of comfyent.RegisterFunctions.
+func NameMatchesRegexp(pattern string) predicate.User {
+	return predicate.User(func(s *sql.Selector) {
+		s.Where(sql.P(func(b *sql.Builder) {
+			b.Ident(s.C(FieldName)).WriteString(" REGEXP ").Arg(pattern)
+		}))
+	})
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/examples/uuid/ent"
+)
+
+func main() {
+
+	comfy, err := comfylite3.New(
+		comfylite3.WithPath("./ent_uuid.db"),
+	)
+	if err != nil {
+		log.Fatalf("failed creating ComfyDB: %v", err)
+	}
+	defer comfy.Close()
+
+	db := comfyent.OpenDB(
+		comfy,
+		comfylite3.WithForeignKeys(),
+	)
+
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.SQLite, db)))
+	defer client.Close()
+
+	ctx := context.Background()
+
+	if err := client.Schema.Create(ctx); err != nil {
+		log.Fatalf("failed creating schema resources: %v", err)
+	}
+
+	// Create (The UUID is generated by the schema default)
+	alice, err := client.User.Create().
+		SetName("Alice").
+		SetAge(30).
+		SetEmail("alice@example.com").
+		Save(ctx)
+	if err != nil {
+		log.Fatalf("failed creating user: %v", err)
+	}
+	fmt.Printf("Created user: %s, ID: %s\n", alice.Name, alice.ID)
+
+	// Read (Fetch the user back by its UUID)
+	found, err := client.User.Get(ctx, alice.ID)
+	if err != nil {
+		log.Fatalf("failed getting user: %v", err)
+	}
+	fmt.Printf("Found user: %s, ID: %s\n", found.Name, found.ID)
+}
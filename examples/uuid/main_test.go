@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/examples/uuid/ent"
+	"github.com/google/uuid"
+)
+
+func TestUUIDPrimaryKey(t *testing.T) {
+	comfy, err := comfylite3.New(
+		comfylite3.WithMemory(),
+		comfylite3.WithConnection("file:uuid_test?mode=memory&cache=shared&_fk=1"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer comfy.Close()
+	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.SQLite, comfyent.OpenDB(comfy))))
+	defer client.Close()
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	alice := client.User.Create().SetName("Alice").SetAge(30).SetEmail("alice@example.com").SaveX(ctx)
+	bob := client.User.Create().SetName("Bob").SetAge(30).SetEmail("bob@example.com").SaveX(ctx)
+	if alice.ID == uuid.Nil || alice.ID == bob.ID {
+		t.Fatalf("got IDs %s and %s, want distinct UUIDs", alice.ID, bob.ID)
+	}
+	found, err := client.User.Get(ctx, alice.ID)
+	if err != nil {
+		t.Fatalf("getting user by UUID: %v", err)
+	}
+	if found.Name != "Alice" {
+		t.Fatalf("got user %q, want Alice", found.Name)
+	}
+}
@@ -0,0 +1,82 @@
+// Package implicittx carries, in a context, the transaction a hook opens around the mutation
+// it wraps. It sits below both the ent schema, whose hooks open such transactions, and the
+// comfyent drivers running statements within them.
+package implicittx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"entgo.io/ent/dialect"
+)
+
+// errDone is returned for the statements run with the context of a Tx once it ended.
+var errDone = errors.New("driver: implicit transaction has already been committed or rolled back")
+
+// Tx is a transaction begun on the first statement run with the context returned by With, on a
+// driver honoring it, which then runs the following ones within.
+type Tx struct {
+	mu   sync.Mutex
+	drv  dialect.Driver
+	tx   dialect.Tx
+	done bool
+}
+
+type key struct{}
+
+// With returns a context carrying the returned Tx. The caller ends it with Commit or Rollback.
+func With(parent context.Context) (context.Context, *Tx) {
+	it := &Tx{}
+	return context.WithValue(parent, key{}, it), it
+}
+
+// From returns the Tx of ctx, if any.
+func From(ctx context.Context) (*Tx, bool) {
+	it, ok := ctx.Value(key{}).(*Tx)
+	return it, ok
+}
+
+// Commit commits the transaction, if a statement began it.
+func (it *Tx) Commit() error {
+	return it.end(dialect.Tx.Commit)
+}
+
+// Rollback rolls the transaction back, if a statement began it.
+func (it *Tx) Rollback() error {
+	return it.end(dialect.Tx.Rollback)
+}
+
+func (it *Tx) end(f func(dialect.Tx) error) error {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if it.done {
+		return errDone
+	}
+	it.done = true
+	if it.tx == nil {
+		return nil
+	}
+	return f(it.tx)
+}
+
+// Open returns the transaction the statements of drv run within, beginning it on drv if needed.
+// ok is false for the statements of other drivers than the one it was begun on.
+func (it *Tx) Open(ctx context.Context, drv dialect.Driver) (tx dialect.Tx, ok bool, err error) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	switch {
+	case it.drv != nil && it.drv != drv:
+		return nil, false, nil
+	case it.done:
+		return nil, true, errDone
+	}
+	if it.tx == nil {
+		if it.tx, err = drv.Tx(ctx); err != nil {
+			return nil, true, fmt.Errorf("driver: beginning implicit transaction: %w", err)
+		}
+		it.drv = drv
+	}
+	return it.tx, true, nil
+}
@@ -4,38 +4,36 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
-	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
-	"github.com/davidroman0O/comfylite3"
+	"github.com/davidroman0O/comfylite3-ent/comfyent"
+	"github.com/davidroman0O/comfylite3-ent/comfyent/tx"
 	"github.com/davidroman0O/comfylite3-ent/ent"
+	"github.com/davidroman0O/comfylite3-ent/ent/address"
+	"github.com/davidroman0O/comfylite3-ent/ent/phone"
+	_ "github.com/davidroman0O/comfylite3-ent/ent/runtime"
+	"github.com/davidroman0O/comfylite3-ent/ent/schema"
+	"github.com/davidroman0O/comfylite3-ent/ent/schematype"
 	"github.com/davidroman0O/comfylite3-ent/ent/user"
 )
 
 func main() {
 
-	comfy, err := comfylite3.New(
-		comfylite3.WithPath("./ent.db"),
-	)
+	client, comfy, err := comfyent.Open(comfyent.Config{
+		Path:        "./ent.db",
+		SharedCache: true,
+		ForeignKeys: true,
+	})
 	if err != nil {
-		log.Fatalf("failed creating ComfyDB: %v", err)
+		log.Fatalf("failed opening database: %v", err)
 	}
 	defer comfy.Close()
-
-	db := comfylite3.OpenDB(
-		comfy,
-		comfylite3.WithOption("_fk=1"),
-		comfylite3.WithOption("cache=shared"),
-		comfylite3.WithOption("mode=rwc"),
-		comfylite3.WithForeignKeys(),
-	)
-
-	client := ent.NewClient(ent.Driver(sql.OpenDB(dialect.SQLite, db)))
 	defer client.Close()
 
 	ctx := context.Background()
 
-	if err := client.Schema.Create(ctx); err != nil {
+	if err := client.Schema.Create(ctx, comfyent.WithCaseInsensitiveEmail()); err != nil {
 		log.Fatalf("failed creating schema resources: %v", err)
 	}
 
@@ -50,6 +48,60 @@ func main() {
 	}
 	fmt.Printf("Created %d users\n", len(users))
 
+	// Edges (Give a user phones, then find users owning a mobile phone)
+	_, err = client.Phone.CreateBulk(
+		client.Phone.Create().SetNumber("+1-555-0100").SetLabel(schematype.PhoneLabelHome).SetOwner(users[0]),
+		client.Phone.Create().SetNumber("+1-555-0101").SetLabel(schematype.PhoneLabelMobile).SetOwner(users[0]),
+	).Save(ctx)
+	if err != nil {
+		log.Fatalf("failed creating phones: %v", err)
+	}
+	mobileUsers, err := client.User.Query().
+		Where(user.HasPhonesWith(phone.LabelEQ(schematype.PhoneLabelMobile))).
+		All(ctx)
+	if err != nil {
+		log.Fatalf("failed querying users with a mobile phone: %v", err)
+	}
+	fmt.Println("Users with a mobile phone:")
+	for _, u := range mobileUsers {
+		fmt.Printf("  User: %s\n", u.Name)
+	}
+
+	// One-to-one edges (Give a user an address, then find users living in a city)
+	_, err = client.Address.Create().
+		SetStreet("1 Rue de Rivoli").
+		SetCity("Paris").
+		SetPostalCode("75001").
+		SetCountry("France").
+		SetUser(users[1]).
+		Save(ctx)
+	if err != nil {
+		log.Fatalf("failed creating address: %v", err)
+	}
+	parisians, err := client.User.Query().
+		Where(user.HasAddressWith(address.City("Paris"))).
+		All(ctx)
+	if err != nil {
+		log.Fatalf("failed querying users living in Paris: %v", err)
+	}
+	fmt.Println("Users living in Paris:")
+	for _, u := range parisians {
+		fmt.Printf("  User: %s\n", u.Name)
+	}
+
+	// Self-referencing edges (Friendship goes both ways)
+	if err := client.User.UpdateOne(users[0]).AddFriends(users[1]).Exec(ctx); err != nil {
+		log.Fatalf("failed adding friends: %v", err)
+	}
+	friends, err := users[0].QueryFriends().All(ctx)
+	if err != nil {
+		log.Fatalf("failed querying friends: %v", err)
+	}
+	fmt.Printf("Friends of %s:\n", users[0].Name)
+	for _, u := range friends {
+		fmt.Printf("  User: %s\n", u.Name)
+	}
+
 	// Read (Query users)
 	filteredUsers, err := client.User.Query().
 		Where(
@@ -76,23 +128,36 @@ func main() {
 	}
 	fmt.Printf("Updated user: %s, New Age: %d, New Email: %s\n", updatedUser.Name, updatedUser.Age, updatedUser.Email)
 
-	// Pagination (List users in pages)
+	// Ordering (Most recently updated users first)
+	recentUsers, err := client.User.Query().
+		Order(ent.Desc(user.FieldUpdatedAt)).
+		All(ctx)
+	if err != nil {
+		log.Fatalf("failed querying recently updated users: %v", err)
+	}
+	fmt.Println("Users by most recently updated:")
+	for _, u := range recentUsers {
+		fmt.Printf("  User: %s, Updated At: %s\n", u.Name, u.UpdatedAt.Format(time.RFC3339))
+	}
+
+	// Pagination (List users in pages, seeking past the last ID of the previous page)
 	pageSize := 2
-	for i := 0; ; i++ {
-		pagedUsers, err := client.User.Query().
-			Limit(pageSize).
-			Offset(i * pageSize).
-			All(ctx)
+	for page, cursor := 1, 0; ; page++ {
+		pagedUsers, next, err := comfyent.PageUsers(ctx, client, cursor, pageSize)
 		if err != nil {
 			log.Fatalf("failed querying users: %v", err)
 		}
 		if len(pagedUsers) == 0 {
 			break
 		}
-		fmt.Printf("Page %d:\n", i+1)
+		fmt.Printf("Page %d:\n", page)
 		for _, u := range pagedUsers {
 			fmt.Printf("  User: %s\n", u.Name)
 		}
+		if next == 0 {
+			break
+		}
+		cursor = next
 	}
 
 	// Aggregation (Calculate average age)
@@ -107,7 +172,7 @@ func main() {
 	fmt.Printf("Average age: %.2f\n", avgAge)
 
 	// Transactions (Perform multiple operations)
-	err = WithTx(ctx, client, func(tx *ent.Tx) error {
+	err = tx.WithTx(ctx, client, func(tx *ent.Tx) error {
 		// Create a new user within the transaction
 		newUser, err := tx.User.Create().
 			SetName("David").
@@ -133,7 +198,7 @@ func main() {
 		log.Fatalf("failed to run transaction: %v", err)
 	}
 
-	// Delete (Remove a user)
+	// Delete (Soft-delete a user, the row is kept with deleted_at set)
 	err = client.User.DeleteOneID(users[2].ID).Exec(ctx)
 	if err != nil {
 		log.Fatalf("failed deleting user: %v", err)
@@ -149,28 +214,18 @@ func main() {
 	for _, u := range allUsers {
 		fmt.Printf("  User: %s, Age: %d, Email: %s\n", u.Name, u.Age, u.Email)
 	}
-}
 
-// WithTx wraps the given function in a transaction.
-func WithTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error) error {
-	tx, err := client.Tx(ctx)
+	// Soft-deleted users are still reachable when skipping the soft-delete filter
+	withDeleted, err := client.User.Query().All(schema.SkipSoftDelete(ctx))
 	if err != nil {
-		return err
+		log.Fatalf("failed querying users including soft-deleted ones: %v", err)
 	}
-	defer func() {
-		if v := recover(); v != nil {
-			tx.Rollback()
-			panic(v)
+	fmt.Println("All users including soft-deleted ones:")
+	for _, u := range withDeleted {
+		if u.DeletedAt != nil {
+			fmt.Printf("  User: %s, Deleted At: %s\n", u.Name, u.DeletedAt.Format(time.RFC3339))
+			continue
 		}
-	}()
-	if err := fn(tx); err != nil {
-		if rerr := tx.Rollback(); rerr != nil {
-			err = fmt.Errorf("rolling back transaction: %w", rerr)
-		}
-		return err
-	}
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("committing transaction: %w", err)
+		fmt.Printf("  User: %s\n", u.Name)
 	}
-	return nil
 }